@@ -16,12 +16,20 @@ type Config struct {
 	// If empty, it defaults to the KUBECONFIG environment variable,
 	// then to ~/.kube/config.
 	KubeConfigPath string
+
+	// HarvesterAPIURL is the base URL of the Harvester API, used for actions that are not
+	// available through the Kubernetes API such as image uploads. If empty, it is derived
+	// from the Kubernetes API server address.
+	HarvesterAPIURL string
 }
 
 // Client represents a Kubernetes client for interacting with Harvester clusters.
 type Client struct {
 	Clientset *kubernetes.Clientset
 	Config    *rest.Config
+
+	// HarvesterAPIURL is the configured base URL of the Harvester API, if any.
+	HarvesterAPIURL string
 }
 
 // NewClient creates a new Kubernetes client.
@@ -37,8 +45,9 @@ func NewClient(cfg *Config) (*Client, error) {
 	}
 
 	return &Client{
-		Clientset: clientset,
-		Config:    config,
+		Clientset:       clientset,
+		Config:          config,
+		HarvesterAPIURL: cfg.HarvesterAPIURL,
 	}, nil
 }
 
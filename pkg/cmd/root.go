@@ -10,8 +10,9 @@ import (
 
 var (
 	// Global flags
-	kubeConfigPath string
-	logLevel       string
+	kubeConfigPath  string
+	harvesterAPIURL string
+	logLevel        string
 
 	// Root command
 	rootCmd = &cobra.Command{
@@ -53,6 +54,7 @@ func init() {
 
 	// Add flags
 	rootCmd.PersistentFlags().StringVar(&kubeConfigPath, "kubeconfig", "", "Path to the kubeconfig file (default is $KUBECONFIG or $HOME/.kube/config)")
+	rootCmd.PersistentFlags().StringVar(&harvesterAPIURL, "harvester-api-url", "", "Base URL of the Harvester API used for image uploads and node actions (default is derived from the kubeconfig server)")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Log level (debug, info, warn, error, fatal, panic)")
 }
 
@@ -61,7 +63,8 @@ func runServer() error {
 
 	// Create server configuration
 	cfg := &mcp.Config{
-		KubeConfigPath: kubeConfigPath,
+		KubeConfigPath:  kubeConfigPath,
+		HarvesterAPIURL: harvesterAPIURL,
 	}
 
 	// Create and start the MCP server
@@ -0,0 +1,152 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// mgmtClusterNetwork is the built-in cluster network of the management interface configured at installation.
+const mgmtClusterNetwork = "mgmt"
+
+// ClusterNetworkDetails is a cluster network together with its VlanConfigs and per-node VlanStatuses.
+type ClusterNetworkDetails struct {
+	ClusterNetwork *unstructured.Unstructured
+	VlanConfigs    []unstructured.Unstructured
+	VlanStatuses   []unstructured.Unstructured
+}
+
+// GetClusterNetwork retrieves a cluster network with its VlanConfigs and VlanStatuses.
+func (h *ResourceHandler) GetClusterNetwork(ctx context.Context, name string) (*ClusterNetworkDetails, error) {
+	clusterNetwork, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeClusterNetwork], "", name)
+	if err != nil {
+		return nil, err
+	}
+
+	vlanConfigs, err := h.ListVlanConfigs(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	vlanStatuses, err := h.ListVlanStatuses(ctx, name, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClusterNetworkDetails{
+		ClusterNetwork: clusterNetwork,
+		VlanConfigs:    vlanConfigs.Items,
+		VlanStatuses:   vlanStatuses.Items,
+	}, nil
+}
+
+// ListClusterNetworks lists cluster networks with their VlanConfigs and VlanStatuses.
+func (h *ResourceHandler) ListClusterNetworks(ctx context.Context) ([]ClusterNetworkDetails, error) {
+	list, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeClusterNetworks], "")
+	if err != nil {
+		return nil, err
+	}
+
+	vlanConfigs, err := h.ListVlanConfigs(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	vlanStatuses, err := h.ListVlanStatuses(ctx, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	vlanConfigsByNetwork := map[string][]unstructured.Unstructured{}
+	for _, vlanConfig := range vlanConfigs.Items {
+		clusterNetwork := getNestedString(vlanConfig.Object, "spec", "clusterNetwork")
+		vlanConfigsByNetwork[clusterNetwork] = append(vlanConfigsByNetwork[clusterNetwork], vlanConfig)
+	}
+	vlanStatusesByNetwork := map[string][]unstructured.Unstructured{}
+	for _, vlanStatus := range vlanStatuses.Items {
+		clusterNetwork := getNestedString(vlanStatus.Object, "status", "clusterNetwork")
+		vlanStatusesByNetwork[clusterNetwork] = append(vlanStatusesByNetwork[clusterNetwork], vlanStatus)
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool {
+		return list.Items[i].GetName() < list.Items[j].GetName()
+	})
+
+	clusterNetworks := make([]ClusterNetworkDetails, 0, len(list.Items))
+	for i := range list.Items {
+		name := list.Items[i].GetName()
+		clusterNetworks = append(clusterNetworks, ClusterNetworkDetails{
+			ClusterNetwork: &list.Items[i],
+			VlanConfigs:    vlanConfigsByNetwork[name],
+			VlanStatuses:   vlanStatusesByNetwork[name],
+		})
+	}
+
+	return clusterNetworks, nil
+}
+
+// ListVlanConfigs lists VlanConfigs sorted by name, optionally restricted to a single cluster network.
+func (h *ResourceHandler) ListVlanConfigs(ctx context.Context, clusterNetwork string) (*unstructured.UnstructuredList, error) {
+	list, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeVlanConfigs], "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VLAN configs: %w", err)
+	}
+
+	filtered := &unstructured.UnstructuredList{Object: list.Object}
+	for _, item := range list.Items {
+		if clusterNetwork == "" || getNestedString(item.Object, "spec", "clusterNetwork") == clusterNetwork {
+			filtered.Items = append(filtered.Items, item)
+		}
+	}
+	sort.Slice(filtered.Items, func(i, j int) bool {
+		return filtered.Items[i].GetName() < filtered.Items[j].GetName()
+	})
+
+	return filtered, nil
+}
+
+// ListVlanStatuses lists the per-node VlanStatuses sorted by node, optionally restricted to a single
+// cluster network and to the nodes whose VLAN setup is not ready.
+func (h *ResourceHandler) ListVlanStatuses(ctx context.Context, clusterNetwork string, notReadyOnly bool) (*unstructured.UnstructuredList, error) {
+	list, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeVlanStatuses], "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VLAN statuses: %w", err)
+	}
+
+	filtered := &unstructured.UnstructuredList{Object: list.Object}
+	for _, item := range list.Items {
+		if clusterNetwork != "" && getNestedString(item.Object, "status", "clusterNetwork") != clusterNetwork {
+			continue
+		}
+		if ready, _ := vlanStatusReady(item.Object); notReadyOnly && ready {
+			continue
+		}
+		filtered.Items = append(filtered.Items, item)
+	}
+	sort.Slice(filtered.Items, func(i, j int) bool {
+		return getNestedString(filtered.Items[i].Object, "status", "node") < getNestedString(filtered.Items[j].Object, "status", "node")
+	})
+
+	return filtered, nil
+}
+
+// vlanStatusReady reports whether the VLAN setup of a node is ready, with the reason when it is not.
+func vlanStatusReady(vlanStatus map[string]interface{}) (bool, string) {
+	conditions, _, _ := unstructured.NestedSlice(vlanStatus, "status", "conditions")
+	for _, condObj := range conditions {
+		cond, ok := condObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if getNestedString(cond, "type") == "ready" {
+			if getNestedString(cond, "status") == "True" {
+				return true, ""
+			}
+			if message := getNestedString(cond, "message"); message != "" {
+				return false, message
+			}
+			return false, getNestedString(cond, "reason")
+		}
+	}
+	return false, "no ready condition reported"
+}
@@ -416,6 +416,10 @@ func (f *NodeFormatter) FormatResource(res *unstructured.Unstructured) string {
 		}
 	}
 	sb.WriteString(fmt.Sprintf("Status: %s\n", status))
+	sb.WriteString(fmt.Sprintf("Schedulable: %t\n", !getNestedBool(res.Object, "spec", "unschedulable")))
+	if maintainStatus := res.GetAnnotations()[annotationMaintainStatus]; maintainStatus != "" {
+		sb.WriteString(fmt.Sprintf("Maintenance Mode: %s\n", maintainStatus))
+	}
 
 	// Detailed conditions
 	sb.WriteString("\nConditions:\n")
@@ -562,6 +566,12 @@ func (f *NodeFormatter) FormatResourceList(list *unstructured.UnstructuredList)
 		// Basic node info
 		sb.WriteString(fmt.Sprintf("• %s\n", node.GetName()))
 		sb.WriteString(fmt.Sprintf("  Status: %s\n", status))
+		if getNestedBool(node.Object, "spec", "unschedulable") {
+			sb.WriteString("  Schedulable: false (cordoned)\n")
+		}
+		if maintainStatus := node.GetAnnotations()[annotationMaintainStatus]; maintainStatus != "" {
+			sb.WriteString(fmt.Sprintf("  Maintenance Mode: %s\n", maintainStatus))
+		}
 
 		if internalIP != "" {
 			sb.WriteString(fmt.Sprintf("  Internal IP: %s\n", internalIP))
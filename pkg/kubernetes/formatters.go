@@ -34,8 +34,22 @@ func NewFormatterRegistry() *FormatterRegistry {
 
 	// Register Harvester specific formatters
 	registry.Register("VirtualMachine", &VirtualMachineFormatter{})
-	registry.Register("Volume", &VolumeFormatter{})
-	registry.Register("Network", &NetworkFormatter{})
+	registry.Register("VirtualMachineInstanceMigration", &VMMigrationFormatter{})
+	registry.Register("VirtualMachineBackup", &VMBackupFormatter{})
+	registry.Register("VirtualMachineRestore", &VMRestoreFormatter{})
+	registry.Register("Setting", &SettingFormatter{})
+	registry.Register("VirtualMachineTemplate", &VMTemplateFormatter{})
+	registry.Register("VirtualMachineTemplateVersion", &VMTemplateVersionFormatter{})
+	registry.Register("PersistentVolumeClaim", &VolumeFormatter{})
+	registry.Register("VolumeSnapshot", &VolumeSnapshotFormatter{})
+	registry.Register("VolumeSnapshotContent", &VolumeSnapshotContentFormatter{})
+	registry.Register("StorageClass", &StorageClassFormatter{})
+	registry.Register("NetworkAttachmentDefinition", &NetworkFormatter{})
+	registry.Register("ClusterNetwork", &ClusterNetworkFormatter{})
+	registry.Register("VlanConfig", &VlanConfigFormatter{})
+	registry.Register("VlanStatus", &VlanStatusFormatter{})
+	registry.Register("LoadBalancer", &LoadBalancerFormatter{})
+	registry.Register("IPPool", &IPPoolFormatter{})
 	registry.Register("VirtualMachineImage", &VMImageFormatter{})
 	registry.Register("CustomResourceDefinition", &CRDFormatter{})
 
@@ -225,27 +239,27 @@ func FormatVirtualMachine(res *unstructured.Unstructured) string {
 	return formatter.FormatResource(res)
 }
 
-// FormatVolumeList formats a list of Volume resources in a human-readable form
+// FormatVolumeList formats a list of volume (PersistentVolumeClaim) resources in a human-readable form
 func FormatVolumeList(list *unstructured.UnstructuredList) string {
-	formatter, _ := defaultRegistry.GetFormatter("Volume")
+	formatter, _ := defaultRegistry.GetFormatter("PersistentVolumeClaim")
 	return formatter.FormatResourceList(list)
 }
 
-// FormatVolume formats a Volume resource in a human-readable form
+// FormatVolume formats a volume (PersistentVolumeClaim) resource in a human-readable form
 func FormatVolume(res *unstructured.Unstructured) string {
-	formatter, _ := defaultRegistry.GetFormatter("Volume")
+	formatter, _ := defaultRegistry.GetFormatter("PersistentVolumeClaim")
 	return formatter.FormatResource(res)
 }
 
-// FormatNetworkList formats a list of Network resources in a human-readable form
+// FormatNetworkList formats a list of network (NetworkAttachmentDefinition) resources in a human-readable form
 func FormatNetworkList(list *unstructured.UnstructuredList) string {
-	formatter, _ := defaultRegistry.GetFormatter("Network")
+	formatter, _ := defaultRegistry.GetFormatter("NetworkAttachmentDefinition")
 	return formatter.FormatResourceList(list)
 }
 
-// FormatNetwork formats a Network resource in a human-readable form
+// FormatNetwork formats a network (NetworkAttachmentDefinition) resource in a human-readable form
 func FormatNetwork(res *unstructured.Unstructured) string {
-	formatter, _ := defaultRegistry.GetFormatter("Network")
+	formatter, _ := defaultRegistry.GetFormatter("NetworkAttachmentDefinition")
 	return formatter.FormatResource(res)
 }
 
@@ -0,0 +1,68 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"k8s.io/client-go/rest"
+)
+
+// newHarvesterAPIClient returns the Harvester API base URL and an HTTP client that authenticates like the
+// Kubernetes client. When endpoint is empty it is derived from the Kubernetes API server address.
+func newHarvesterAPIClient(config *rest.Config, endpoint string) (string, *http.Client, error) {
+	if endpoint == "" {
+		endpoint = HarvesterAPIURLFromHost(config.Host)
+	}
+
+	httpClient, err := rest.HTTPClientFor(config)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+	return strings.TrimSuffix(endpoint, "/"), httpClient, nil
+}
+
+// DoHarvesterAction invokes an action on a resource of the Harvester API, for actions that are not
+// available through the Kubernetes API. resourcePath is relative to /v1/harvester, e.g. "nodes/node1".
+func (h *ResourceHandler) DoHarvesterAction(ctx context.Context, resourcePath, action string, body interface{}) error {
+	endpoint, httpClient, err := newHarvesterAPIClient(h.client.Config, h.client.HarvesterAPIURL)
+	if err != nil {
+		return err
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	actionURL := fmt.Sprintf("%s/v1/harvester/%s?action=%s", endpoint, resourcePath, url.QueryEscape(action))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, actionURL, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", action, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to invoke %s: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("failed to invoke %s: %s: %s", action, resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	return nil
+}
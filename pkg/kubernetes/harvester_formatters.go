@@ -1,37 +1,110 @@
 package kubernetes
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
 )
 
 // VirtualMachineFormatter handles formatting for VirtualMachine resources
 type VirtualMachineFormatter struct{}
 
 func (f *VirtualMachineFormatter) FormatResource(res *unstructured.Unstructured) string {
+	return FormatVMRuntime(&VMRuntime{VM: res})
+}
+
+// FormatVMRuntime formats a virtual machine together with the runtime state of its instance in a human-readable form
+func FormatVMRuntime(vm *VMRuntime) string {
+	res := vm.VM
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("Virtual Machine: %s\n", res.GetName()))
 	sb.WriteString(fmt.Sprintf("Namespace: %s\n", res.GetNamespace()))
 
 	// Get status
-	status := "Unknown"
 	running := getNestedBool(res.Object, "status", "ready")
 	created := getNestedBool(res.Object, "status", "created")
-
-	if running {
-		status = "Running"
-	} else if created {
-		status = "Created"
-	}
-	sb.WriteString(fmt.Sprintf("Status: %s\n", status))
+	sb.WriteString(fmt.Sprintf("Status: %s\n", vmStatus(res, vm.Instance)))
 
 	// Running and created fields
 	sb.WriteString(fmt.Sprintf("Ready: %t\n", running))
 	sb.WriteString(fmt.Sprintf("Created: %t\n", created))
 
+	// VM conditions explaining the status, e.g. why it cannot be scheduled
+	vmConditions, _, _ := unstructured.NestedSlice(res.Object, "status", "conditions")
+	for _, condObj := range vmConditions {
+		cond, ok := condObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if message := getNestedString(cond, "message"); message != "" {
+			sb.WriteString(fmt.Sprintf("Condition %s=%s: %s\n", getNestedString(cond, "type"), getNestedString(cond, "status"), message))
+		}
+	}
+
+	// Runtime state of the VirtualMachineInstance and its launcher pod
+	if vm.Instance != nil {
+		instance := vm.Instance.Object
+		sb.WriteString("\nRuntime:\n")
+		sb.WriteString(fmt.Sprintf("  Phase: %s\n", getNestedString(instance, "status", "phase")))
+		if nodeName := getNestedString(instance, "status", "nodeName"); nodeName != "" {
+			sb.WriteString(fmt.Sprintf("  Node: %s\n", nodeName))
+		}
+
+		interfaces, _, _ := unstructured.NestedSlice(instance, "status", "interfaces")
+		for _, ifaceObj := range interfaces {
+			iface, ok := ifaceObj.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			ips := getNestedStringSlice(iface, "ipAddresses")
+			if len(ips) == 0 {
+				if ip := getNestedString(iface, "ipAddress"); ip != "" {
+					ips = []string{ip}
+				}
+			}
+			if len(ips) > 0 {
+				sb.WriteString(fmt.Sprintf("  IP Addresses (%s): %s\n", getNestedString(iface, "name"), strings.Join(ips, ", ")))
+			}
+		}
+
+		if migration := getNestedMap(instance, "status", "migrationState"); len(migration) > 0 {
+			migrationStatus := "In Progress"
+			if getNestedBool(migration, "failed") {
+				migrationStatus = "Failed"
+			} else if getNestedBool(migration, "completed") {
+				migrationStatus = "Completed"
+			}
+			sb.WriteString(fmt.Sprintf("  Last Migration: %s (%s -> %s)\n", migrationStatus,
+				getNestedString(migration, "sourceNode"), getNestedString(migration, "targetNode")))
+		}
+
+		if pod := vm.LauncherPod; pod != nil {
+			sb.WriteString(fmt.Sprintf("  Launcher Pod: %s (%s)\n", pod.GetName(), getNestedString(pod.Object, "status", "phase")))
+		}
+
+		conditions, _, _ := unstructured.NestedSlice(instance, "status", "conditions")
+		if len(conditions) > 0 {
+			sb.WriteString("  Conditions:\n")
+			for _, condObj := range conditions {
+				cond, ok := condObj.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				sb.WriteString(fmt.Sprintf("    %s: %s", getNestedString(cond, "type"), getNestedString(cond, "status")))
+				if message := getNestedString(cond, "message"); message != "" {
+					sb.WriteString(fmt.Sprintf(" (%s)", message))
+				}
+				sb.WriteString("\n")
+			}
+		}
+	}
+
 	// Detailed VM specification
 	sb.WriteString("\nSpecification:\n")
 
@@ -47,16 +120,24 @@ func (f *VirtualMachineFormatter) FormatResource(res *unstructured.Unstructured)
 		sb.WriteString(fmt.Sprintf("  Memory: %s\n", memory))
 	}
 
-	// Volumes
-	volumes, _, _ := unstructured.NestedSlice(res.Object, "spec", "template", "spec", "volumes")
+	// Volumes, with hotplugged volumes listed separately
+	var volumes, hotplugVolumes []map[string]interface{}
+	volumeObjs, _, _ := unstructured.NestedSlice(res.Object, "spec", "template", "spec", "volumes")
+	for _, volumeObj := range volumeObjs {
+		volume, ok := volumeObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if isHotplugVolume(volume) {
+			hotplugVolumes = append(hotplugVolumes, volume)
+		} else {
+			volumes = append(volumes, volume)
+		}
+	}
+
 	if len(volumes) > 0 {
 		sb.WriteString("\nVolumes:\n")
-		for _, volumeObj := range volumes {
-			volume, ok := volumeObj.(map[string]interface{})
-			if !ok {
-				continue
-			}
-
+		for _, volume := range volumes {
 			name, _, _ := unstructured.NestedString(volume, "name")
 			sb.WriteString(fmt.Sprintf("  %s:\n", name))
 
@@ -85,6 +166,35 @@ func (f *VirtualMachineFormatter) FormatResource(res *unstructured.Unstructured)
 		}
 	}
 
+	if len(hotplugVolumes) > 0 {
+		sb.WriteString("\nHotplugged Volumes:\n")
+		for _, volume := range hotplugVolumes {
+			claimName := getNestedString(volume, "persistentVolumeClaim", "claimName")
+			if claimName == "" {
+				claimName = getNestedString(volume, "dataVolume", "name")
+			}
+			sb.WriteString(fmt.Sprintf("  %s:\n", getNestedString(volume, "name")))
+			sb.WriteString(fmt.Sprintf("    Claim Name: %s\n", claimName))
+		}
+	}
+
+	// Pending hotplug requests that have not been applied yet
+	volumeRequests, _, _ := unstructured.NestedSlice(res.Object, "status", "volumeRequests")
+	if len(volumeRequests) > 0 {
+		sb.WriteString("\nPending Volume Requests:\n")
+		for _, requestObj := range volumeRequests {
+			request, ok := requestObj.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if name := getNestedString(request, "addVolumeOptions", "name"); name != "" {
+				sb.WriteString(fmt.Sprintf("  Attach %s\n", name))
+			} else if name := getNestedString(request, "removeVolumeOptions", "name"); name != "" {
+				sb.WriteString(fmt.Sprintf("  Detach %s\n", name))
+			}
+		}
+	}
+
 	// Networks
 	networks, _, _ := unstructured.NestedSlice(res.Object, "spec", "template", "spec", "networks")
 	if len(networks) > 0 {
@@ -119,35 +229,38 @@ func (f *VirtualMachineFormatter) FormatResource(res *unstructured.Unstructured)
 }
 
 func (f *VirtualMachineFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
-	if len(list.Items) == 0 {
+	vms := make([]VMRuntime, 0, len(list.Items))
+	for i := range list.Items {
+		vms = append(vms, VMRuntime{VM: &list.Items[i]})
+	}
+	return FormatVMRuntimeList(vms)
+}
+
+// FormatVMRuntimeList formats virtual machines together with the runtime state of their instances in a human-readable form
+func FormatVMRuntimeList(runtimes []VMRuntime) string {
+	if len(runtimes) == 0 {
 		return "No virtual machines found in the specified namespace(s)."
 	}
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Found %d virtual machine(s):\n\n", len(list.Items)))
+	sb.WriteString(fmt.Sprintf("Found %d virtual machine(s):\n\n", len(runtimes)))
 
 	// Group VMs by namespace
-	vmsByNamespace := make(map[string][]unstructured.Unstructured)
-	for _, item := range list.Items {
-		namespace := item.GetNamespace()
+	vmsByNamespace := make(map[string][]VMRuntime)
+	for _, item := range runtimes {
+		namespace := item.VM.GetNamespace()
 		vmsByNamespace[namespace] = append(vmsByNamespace[namespace], item)
 	}
 
 	// Print VMs grouped by namespace
-	for namespace, vms := range vmsByNamespace {
-		sb.WriteString(fmt.Sprintf("Namespace: %s (%d VMs)\n", namespace, len(vms)))
+	for namespace, vmRuntimes := range vmsByNamespace {
+		sb.WriteString(fmt.Sprintf("Namespace: %s (%d VMs)\n", namespace, len(vmRuntimes)))
 
-		for _, vm := range vms {
-			// Get status
-			status := "Unknown"
-			running := getNestedBool(vm.Object, "status", "ready")
-			created := getNestedBool(vm.Object, "status", "created")
+		for _, runtime := range vmRuntimes {
+			vm := runtime.VM
 
-			if running {
-				status = "Running"
-			} else if created {
-				status = "Created"
-			}
+			// Get status
+			status := vmStatus(vm, runtime.Instance)
 
 			// Get spec details
 			cpuCores := getNestedInt64(vm.Object, "spec", "template", "spec", "domain", "cpu", "cores")
@@ -157,6 +270,16 @@ func (f *VirtualMachineFormatter) FormatResourceList(list *unstructured.Unstruct
 			sb.WriteString(fmt.Sprintf("  • %s\n", vm.GetName()))
 			sb.WriteString(fmt.Sprintf("    Status: %s\n", status))
 
+			// Runtime state of the instance
+			if runtime.Instance != nil {
+				if nodeName := getNestedString(runtime.Instance.Object, "status", "nodeName"); nodeName != "" {
+					sb.WriteString(fmt.Sprintf("    Node: %s\n", nodeName))
+				}
+			}
+			if ips := vmIPAddresses(runtime.Instance); len(ips) > 0 {
+				sb.WriteString(fmt.Sprintf("    IP: %s\n", strings.Join(ips, ", ")))
+			}
+
 			if cpuCores > 0 {
 				sb.WriteString(fmt.Sprintf("    CPU Cores: %d\n", cpuCores))
 			}
@@ -180,7 +303,11 @@ func (f *VirtualMachineFormatter) FormatResourceList(list *unstructured.Unstruct
 					// Check different volume types
 					if pvc, exists, _ := unstructured.NestedMap(volume, "persistentVolumeClaim"); exists && pvc != nil {
 						claimName := getNestedString(volume, "persistentVolumeClaim", "claimName")
-						sb.WriteString(fmt.Sprintf("      %s: PVC %s\n", name, claimName))
+						if isHotplugVolume(volume) {
+							sb.WriteString(fmt.Sprintf("      %s: PVC %s (hotplugged)\n", name, claimName))
+						} else {
+							sb.WriteString(fmt.Sprintf("      %s: PVC %s\n", name, claimName))
+						}
 					} else if container, exists, _ := unstructured.NestedMap(volume, "containerDisk"); exists && container != nil {
 						image := getNestedString(volume, "containerDisk", "image")
 						sb.WriteString(fmt.Sprintf("      %s: ContainerDisk %s\n", name, image))
@@ -229,37 +356,71 @@ func (f *VirtualMachineFormatter) FormatResourceList(list *unstructured.Unstruct
 	return sb.String()
 }
 
-// VolumeFormatter handles formatting for Volume resources
+// VolumeFormatter handles formatting for volumes, which are PersistentVolumeClaims backed by Longhorn volumes
 type VolumeFormatter struct{}
 
 func (f *VolumeFormatter) FormatResource(res *unstructured.Unstructured) string {
+	return FormatVolumeDetails(&VolumeDetails{PVC: res})
+}
+
+// FormatVolumeDetails formats a volume together with its Longhorn volume and the VMs using it in a human-readable form
+func FormatVolumeDetails(volume *VolumeDetails) string {
+	res := volume.PVC
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("Volume: %s\n", res.GetName()))
 	sb.WriteString(fmt.Sprintf("Namespace: %s\n", res.GetNamespace()))
-
-	// Get size and status
-	size := getNestedString(res.Object, "spec", "size")
-	status := getNestedString(res.Object, "status", "state")
-
-	if status != "" {
-		sb.WriteString(fmt.Sprintf("Status: %s\n", status))
-	}
-	if size != "" {
-		sb.WriteString(fmt.Sprintf("Size: %s\n", size))
-	}
+	sb.WriteString(fmt.Sprintf("Status: %s\n", getNestedString(res.Object, "status", "phase")))
+	sb.WriteString(fmt.Sprintf("Size: %s\n", volumeSize(res)))
 
 	// Storage Class
 	storageClass := getNestedString(res.Object, "spec", "storageClassName")
 	if storageClass != "" {
 		sb.WriteString(fmt.Sprintf("Storage Class: %s\n", storageClass))
 	}
+	if volumeMode := getNestedString(res.Object, "spec", "volumeMode"); volumeMode != "" {
+		sb.WriteString(fmt.Sprintf("Volume Mode: %s\n", volumeMode))
+	}
+	if accessModes := getNestedStringSlice(res.Object, "spec", "accessModes"); len(accessModes) > 0 {
+		sb.WriteString(fmt.Sprintf("Access Modes: %s\n", strings.Join(accessModes, ", ")))
+	}
+	if imageID := res.GetAnnotations()[annotationImageID]; imageID != "" {
+		sb.WriteString(fmt.Sprintf("Image: %s\n", imageID))
+	}
+
+	// Virtual machines using the volume
+	if len(volume.VMs) > 0 {
+		sb.WriteString(fmt.Sprintf("Used By VMs: %s\n", strings.Join(volume.VMs, ", ")))
+	} else {
+		sb.WriteString("Used By VMs: None\n")
+	}
+
+	// Backing Longhorn volume
+	if volume.LonghornVolume != nil {
+		lhVolume := volume.LonghornVolume.Object
+		sb.WriteString("\nLonghorn Volume:\n")
+		sb.WriteString(fmt.Sprintf("  Name: %s\n", getNestedString(lhVolume, "metadata", "name")))
+		sb.WriteString(fmt.Sprintf("  State: %s\n", getNestedString(lhVolume, "status", "state")))
+		sb.WriteString(fmt.Sprintf("  Robustness: %s\n", getNestedString(lhVolume, "status", "robustness")))
+		sb.WriteString(fmt.Sprintf("  Replicas: %d\n", getNestedInt64(lhVolume, "spec", "numberOfReplicas")))
+		if node := getNestedString(lhVolume, "status", "currentNodeID"); node != "" {
+			sb.WriteString(fmt.Sprintf("  Attached Node: %s\n", node))
+		}
+		if actualSize := getNestedInt64(lhVolume, "status", "actualSize"); actualSize > 0 {
+			sb.WriteString(fmt.Sprintf("  Actual Size: %s\n", resource.NewQuantity(actualSize, resource.BinarySI).String()))
+		}
+		if dataLocality := getNestedString(lhVolume, "spec", "dataLocality"); dataLocality != "" {
+			sb.WriteString(fmt.Sprintf("  Data Locality: %s\n", dataLocality))
+		}
 
-	// Additional details
-	accessModes, _, _ := unstructured.NestedStringSlice(res.Object, "spec", "accessModes")
-	if len(accessModes) > 0 {
-		sb.WriteString("\nAccess Modes:\n")
-		for _, mode := range accessModes {
-			sb.WriteString(fmt.Sprintf("  %s\n", mode))
+		conditions, _, _ := unstructured.NestedSlice(lhVolume, "status", "conditions")
+		for _, condObj := range conditions {
+			cond, ok := condObj.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if message := getNestedString(cond, "message"); message != "" {
+				sb.WriteString(fmt.Sprintf("  Condition %s=%s: %s\n", getNestedString(cond, "type"), getNestedString(cond, "status"), message))
+			}
 		}
 	}
 
@@ -271,17 +432,26 @@ func (f *VolumeFormatter) FormatResource(res *unstructured.Unstructured) string
 }
 
 func (f *VolumeFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
-	if len(list.Items) == 0 {
+	volumes := make([]VolumeDetails, 0, len(list.Items))
+	for i := range list.Items {
+		volumes = append(volumes, VolumeDetails{PVC: &list.Items[i]})
+	}
+	return FormatVolumeDetailsList(volumes)
+}
+
+// FormatVolumeDetailsList formats volumes together with their Longhorn volumes and the VMs using them in a human-readable form
+func FormatVolumeDetailsList(details []VolumeDetails) string {
+	if len(details) == 0 {
 		return "No volumes found in the specified namespace(s)."
 	}
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Found %d volume(s):\n\n", len(list.Items)))
+	sb.WriteString(fmt.Sprintf("Found %d volume(s):\n\n", len(details)))
 
 	// Group volumes by namespace
-	volumesByNamespace := make(map[string][]unstructured.Unstructured)
-	for _, item := range list.Items {
-		namespace := item.GetNamespace()
+	volumesByNamespace := make(map[string][]VolumeDetails)
+	for _, item := range details {
+		namespace := item.PVC.GetNamespace()
 		volumesByNamespace[namespace] = append(volumesByNamespace[namespace], item)
 	}
 
@@ -289,18 +459,33 @@ func (f *VolumeFormatter) FormatResourceList(list *unstructured.UnstructuredList
 	for namespace, volumes := range volumesByNamespace {
 		sb.WriteString(fmt.Sprintf("Namespace: %s (%d volumes)\n", namespace, len(volumes)))
 
-		for _, volume := range volumes {
-			// Get size and status
-			size := getNestedString(volume.Object, "spec", "size")
-			status := getNestedString(volume.Object, "status", "state")
-
+		for _, detail := range volumes {
+			volume := detail.PVC
 			// Basic volume info
 			sb.WriteString(fmt.Sprintf("  • %s\n", volume.GetName()))
-			if status != "" {
-				sb.WriteString(fmt.Sprintf("    Status: %s\n", status))
+			sb.WriteString(fmt.Sprintf("    Status: %s\n", getNestedString(volume.Object, "status", "phase")))
+			sb.WriteString(fmt.Sprintf("    Size: %s\n", volumeSize(volume)))
+			if storageClass := getNestedString(volume.Object, "spec", "storageClassName"); storageClass != "" {
+				sb.WriteString(fmt.Sprintf("    Storage Class: %s\n", storageClass))
 			}
-			if size != "" {
-				sb.WriteString(fmt.Sprintf("    Size: %s\n", size))
+			if len(detail.VMs) > 0 {
+				sb.WriteString(fmt.Sprintf("    VM: %s\n", strings.Join(detail.VMs, ", ")))
+			}
+
+			// Longhorn state
+			if detail.LonghornVolume != nil {
+				lhVolume := detail.LonghornVolume.Object
+				sb.WriteString(fmt.Sprintf("    Longhorn: %s, %s, %d replicas",
+					getNestedString(lhVolume, "status", "state"),
+					getNestedString(lhVolume, "status", "robustness"),
+					getNestedInt64(lhVolume, "spec", "numberOfReplicas")))
+				if node := getNestedString(lhVolume, "status", "currentNodeID"); node != "" {
+					sb.WriteString(fmt.Sprintf(", attached to %s", node))
+				}
+				sb.WriteString("\n")
+				if actualSize := getNestedInt64(lhVolume, "status", "actualSize"); actualSize > 0 {
+					sb.WriteString(fmt.Sprintf("    Actual Size: %s\n", resource.NewQuantity(actualSize, resource.BinarySI).String()))
+				}
 			}
 
 			// Creation time
@@ -316,27 +501,59 @@ func (f *VolumeFormatter) FormatResourceList(list *unstructured.UnstructuredList
 	return sb.String()
 }
 
-// NetworkFormatter handles formatting for Network resources
-type NetworkFormatter struct{}
+// volumeSize returns the provisioned capacity of a volume claim, falling back to the requested size.
+func volumeSize(res *unstructured.Unstructured) string {
+	if capacity := getNestedString(res.Object, "status", "capacity", "storage"); capacity != "" {
+		return capacity
+	}
+	return getNestedString(res.Object, "spec", "resources", "requests", "storage")
+}
 
-func (f *NetworkFormatter) FormatResource(res *unstructured.Unstructured) string {
+// VolumeSnapshotFormatter handles formatting for VolumeSnapshot resources
+type VolumeSnapshotFormatter struct{}
+
+func (f *VolumeSnapshotFormatter) FormatResource(res *unstructured.Unstructured) string {
+	return FormatVolumeSnapshotDetails(&VolumeSnapshotDetails{Snapshot: res})
+}
+
+// FormatVolumeSnapshotDetails formats a volume snapshot together with its bound snapshot content in a human-readable form
+func FormatVolumeSnapshotDetails(details *VolumeSnapshotDetails) string {
+	res := details.Snapshot
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Network: %s\n", res.GetName()))
+	sb.WriteString(fmt.Sprintf("Volume Snapshot: %s\n", res.GetName()))
 	sb.WriteString(fmt.Sprintf("Namespace: %s\n", res.GetNamespace()))
-
-	// Get network type and config
-	networkType := getNestedString(res.Object, "spec", "type")
-	if networkType != "" {
-		sb.WriteString(fmt.Sprintf("Type: %s\n", networkType))
+	sb.WriteString(fmt.Sprintf("Source Volume: %s\n", getNestedString(res.Object, "spec", "source", "persistentVolumeClaimName")))
+	sb.WriteString(fmt.Sprintf("Ready To Use: %t\n", getNestedBool(res.Object, "status", "readyToUse")))
+	if restoreSize := getNestedString(res.Object, "status", "restoreSize"); restoreSize != "" {
+		sb.WriteString(fmt.Sprintf("Restore Size: %s\n", restoreSize))
+	}
+	if snapshotClass := getNestedString(res.Object, "spec", "volumeSnapshotClassName"); snapshotClass != "" {
+		sb.WriteString(fmt.Sprintf("Snapshot Class: %s\n", snapshotClass))
+	}
+	if creationTime := getNestedString(res.Object, "status", "creationTime"); creationTime != "" {
+		sb.WriteString(fmt.Sprintf("Snapshot Time: %s\n", creationTime))
+	}
+	for _, owner := range res.GetOwnerReferences() {
+		if owner.Kind == "VirtualMachineBackup" {
+			sb.WriteString(fmt.Sprintf("Part Of VM Snapshot/Backup: %s\n", owner.Name))
+		}
+	}
+	if errMessage := getNestedString(res.Object, "status", "error", "message"); errMessage != "" {
+		sb.WriteString(fmt.Sprintf("Error: %s\n", errMessage))
 	}
 
-	// Config details
-	config, configFound, _ := unstructured.NestedMap(res.Object, "spec", "config")
-	if configFound && len(config) > 0 {
-		sb.WriteString("\nConfiguration:\n")
-		for key, value := range config {
-			sb.WriteString(fmt.Sprintf("  %s: %v\n", key, value))
+	// Bound snapshot content
+	if details.Content != nil {
+		content := details.Content.Object
+		sb.WriteString("\nSnapshot Content:\n")
+		sb.WriteString(fmt.Sprintf("  Name: %s\n", getNestedString(content, "metadata", "name")))
+		sb.WriteString(fmt.Sprintf("  Driver: %s\n", getNestedString(content, "spec", "driver")))
+		sb.WriteString(fmt.Sprintf("  Deletion Policy: %s\n", getNestedString(content, "spec", "deletionPolicy")))
+		if handle := getNestedString(content, "status", "snapshotHandle"); handle != "" {
+			sb.WriteString(fmt.Sprintf("  Snapshot Handle: %s\n", handle))
 		}
+	} else if contentName := getNestedString(res.Object, "status", "boundVolumeSnapshotContentName"); contentName != "" {
+		sb.WriteString(fmt.Sprintf("Snapshot Content: %s\n", contentName))
 	}
 
 	// Creation time
@@ -346,43 +563,39 @@ func (f *NetworkFormatter) FormatResource(res *unstructured.Unstructured) string
 	return sb.String()
 }
 
-func (f *NetworkFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
+func (f *VolumeSnapshotFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
 	if len(list.Items) == 0 {
-		return "No networks found in the specified namespace(s)."
+		return "No volume snapshots found in the specified namespace(s)."
 	}
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Found %d network(s):\n\n", len(list.Items)))
+	sb.WriteString(fmt.Sprintf("Found %d volume snapshot(s):\n\n", len(list.Items)))
 
-	// Group networks by namespace
-	networksByNamespace := make(map[string][]unstructured.Unstructured)
+	// Group snapshots by namespace
+	snapshotsByNamespace := make(map[string][]unstructured.Unstructured)
 	for _, item := range list.Items {
 		namespace := item.GetNamespace()
-		networksByNamespace[namespace] = append(networksByNamespace[namespace], item)
+		snapshotsByNamespace[namespace] = append(snapshotsByNamespace[namespace], item)
 	}
 
-	// Print networks grouped by namespace
-	for namespace, networks := range networksByNamespace {
-		sb.WriteString(fmt.Sprintf("Namespace: %s (%d networks)\n", namespace, len(networks)))
-
-		for _, network := range networks {
-			// Get type and config
-			networkType := getNestedString(network.Object, "spec", "type")
-
-			// Basic network info
-			sb.WriteString(fmt.Sprintf("  • %s\n", network.GetName()))
-			if networkType != "" {
-				sb.WriteString(fmt.Sprintf("    Type: %s\n", networkType))
+	// Print snapshots grouped by namespace
+	for namespace, snapshots := range snapshotsByNamespace {
+		sb.WriteString(fmt.Sprintf("Namespace: %s (%d snapshots)\n", namespace, len(snapshots)))
+
+		for _, snapshot := range snapshots {
+			// Basic snapshot info
+			sb.WriteString(fmt.Sprintf("  • %s\n", snapshot.GetName()))
+			sb.WriteString(fmt.Sprintf("    Source Volume: %s\n", getNestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName")))
+			sb.WriteString(fmt.Sprintf("    Ready To Use: %t\n", getNestedBool(snapshot.Object, "status", "readyToUse")))
+			if restoreSize := getNestedString(snapshot.Object, "status", "restoreSize"); restoreSize != "" {
+				sb.WriteString(fmt.Sprintf("    Restore Size: %s\n", restoreSize))
 			}
-
-			// VLAN ID if present
-			vlanId := getNestedInt64(network.Object, "spec", "config", "vlan")
-			if vlanId > 0 {
-				sb.WriteString(fmt.Sprintf("    VLAN ID: %d\n", vlanId))
+			if errMessage := getNestedString(snapshot.Object, "status", "error", "message"); errMessage != "" {
+				sb.WriteString(fmt.Sprintf("    Error: %s\n", errMessage))
 			}
 
 			// Creation time
-			creationTime := network.GetCreationTimestamp().Format(time.RFC3339)
+			creationTime := snapshot.GetCreationTimestamp().Format(time.RFC3339)
 			sb.WriteString(fmt.Sprintf("    Created: %s\n", creationTime))
 
 			sb.WriteString("\n")
@@ -394,47 +607,136 @@ func (f *NetworkFormatter) FormatResourceList(list *unstructured.UnstructuredLis
 	return sb.String()
 }
 
-// VMImageFormatter handles formatting for VirtualMachineImage resources
-type VMImageFormatter struct{}
+// VolumeSnapshotContentFormatter handles formatting for VolumeSnapshotContent resources
+type VolumeSnapshotContentFormatter struct{}
 
-func (f *VMImageFormatter) FormatResource(res *unstructured.Unstructured) string {
+func (f *VolumeSnapshotContentFormatter) FormatResource(res *unstructured.Unstructured) string {
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("VM Image: %s\n", res.GetName()))
-	sb.WriteString(fmt.Sprintf("Namespace: %s\n", res.GetNamespace()))
-
-	// Get image details
-	displayName := getNestedString(res.Object, "spec", "displayName")
-	url := getNestedString(res.Object, "spec", "url")
-	description := getNestedString(res.Object, "spec", "description")
-
-	if displayName != "" {
-		sb.WriteString(fmt.Sprintf("Display Name: %s\n", displayName))
+	sb.WriteString(fmt.Sprintf("Volume Snapshot Content: %s\n", res.GetName()))
+	sb.WriteString(fmt.Sprintf("Volume Snapshot: %s/%s\n",
+		getNestedString(res.Object, "spec", "volumeSnapshotRef", "namespace"),
+		getNestedString(res.Object, "spec", "volumeSnapshotRef", "name")))
+	sb.WriteString(fmt.Sprintf("Driver: %s\n", getNestedString(res.Object, "spec", "driver")))
+	sb.WriteString(fmt.Sprintf("Deletion Policy: %s\n", getNestedString(res.Object, "spec", "deletionPolicy")))
+	if snapshotClass := getNestedString(res.Object, "spec", "volumeSnapshotClassName"); snapshotClass != "" {
+		sb.WriteString(fmt.Sprintf("Snapshot Class: %s\n", snapshotClass))
 	}
-	if url != "" {
-		sb.WriteString(fmt.Sprintf("URL: %s\n", url))
+	if volumeHandle := getNestedString(res.Object, "spec", "source", "volumeHandle"); volumeHandle != "" {
+		sb.WriteString(fmt.Sprintf("Source Volume Handle: %s\n", volumeHandle))
 	}
-	if description != "" {
-		sb.WriteString(fmt.Sprintf("Description: %s\n", description))
+	sb.WriteString(fmt.Sprintf("Ready To Use: %t\n", getNestedBool(res.Object, "status", "readyToUse")))
+	if restoreSize := getNestedInt64(res.Object, "status", "restoreSize"); restoreSize > 0 {
+		sb.WriteString(fmt.Sprintf("Restore Size: %s\n", resource.NewQuantity(restoreSize, resource.BinarySI).String()))
+	}
+	if handle := getNestedString(res.Object, "status", "snapshotHandle"); handle != "" {
+		sb.WriteString(fmt.Sprintf("Snapshot Handle: %s\n", handle))
+	}
+	if errMessage := getNestedString(res.Object, "status", "error", "message"); errMessage != "" {
+		sb.WriteString(fmt.Sprintf("Error: %s\n", errMessage))
 	}
 
-	// Status details
-	status, statusFound, _ := unstructured.NestedMap(res.Object, "status")
-	if statusFound && len(status) > 0 {
-		sb.WriteString("\nStatus:\n")
+	// Creation time
+	creationTime := res.GetCreationTimestamp().Format(time.RFC3339)
+	sb.WriteString(fmt.Sprintf("\nCreated: %s\n", creationTime))
 
-		state := getNestedString(res.Object, "status", "state")
-		if state != "" {
-			sb.WriteString(fmt.Sprintf("  State: %s\n", state))
+	return sb.String()
+}
+
+func (f *VolumeSnapshotContentFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
+	if len(list.Items) == 0 {
+		return "No volume snapshot contents found."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d volume snapshot content(s):\n\n", len(list.Items)))
+
+	for _, content := range list.Items {
+		sb.WriteString(fmt.Sprintf("• %s\n", content.GetName()))
+		sb.WriteString(fmt.Sprintf("  Volume Snapshot: %s/%s\n",
+			getNestedString(content.Object, "spec", "volumeSnapshotRef", "namespace"),
+			getNestedString(content.Object, "spec", "volumeSnapshotRef", "name")))
+		sb.WriteString(fmt.Sprintf("  Ready To Use: %t\n", getNestedBool(content.Object, "status", "readyToUse")))
+		sb.WriteString(fmt.Sprintf("  Deletion Policy: %s\n", getNestedString(content.Object, "spec", "deletionPolicy")))
+		if restoreSize := getNestedInt64(content.Object, "status", "restoreSize"); restoreSize > 0 {
+			sb.WriteString(fmt.Sprintf("  Restore Size: %s\n", resource.NewQuantity(restoreSize, resource.BinarySI).String()))
 		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
 
-		progress := getNestedString(res.Object, "status", "progress")
-		if progress != "" {
-			sb.WriteString(fmt.Sprintf("  Progress: %s\n", progress))
+// StorageClassFormatter handles formatting for StorageClass resources, including Longhorn parameters
+type StorageClassFormatter struct{}
+
+// longhornStorageClassParameters are the Longhorn storage class parameters shown with a friendly label, in display order.
+var longhornStorageClassParameters = []struct {
+	key   string
+	label string
+}{
+	{"numberOfReplicas", "Replicas"},
+	{"staleReplicaTimeout", "Stale Replica Timeout (minutes)"},
+	{"diskSelector", "Disk Selector"},
+	{"nodeSelector", "Node Selector"},
+	{"migratable", "Migratable"},
+	{"dataLocality", "Data Locality"},
+	{"backingImage", "Backing Image"},
+}
+
+func (f *StorageClassFormatter) FormatResource(res *unstructured.Unstructured) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Storage Class: %s\n", res.GetName()))
+	if isDefaultStorageClass(res) {
+		sb.WriteString("Default: Yes (used for new volumes and images unless another class is chosen)\n")
+	} else {
+		sb.WriteString("Default: No\n")
+	}
+	sb.WriteString(fmt.Sprintf("Provisioner: %s\n", getNestedString(res.Object, "provisioner")))
+	if reclaimPolicy := getNestedString(res.Object, "reclaimPolicy"); reclaimPolicy != "" {
+		sb.WriteString(fmt.Sprintf("Reclaim Policy: %s\n", reclaimPolicy))
+	}
+	if bindingMode := getNestedString(res.Object, "volumeBindingMode"); bindingMode != "" {
+		sb.WriteString(fmt.Sprintf("Volume Binding Mode: %s\n", bindingMode))
+	}
+	sb.WriteString(fmt.Sprintf("Allow Volume Expansion: %t\n", getNestedBool(res.Object, "allowVolumeExpansion")))
+
+	parameters := getNestedMap(res.Object, "parameters")
+	shown := map[string]bool{}
+
+	// Longhorn parameters
+	if getNestedString(res.Object, "provisioner") == longhornProvisioner {
+		sb.WriteString("\nLonghorn Parameters:\n")
+		for _, param := range longhornStorageClassParameters {
+			value, ok := parameters[param.key].(string)
+			shown[param.key] = true
+			switch {
+			case ok && value != "":
+				sb.WriteString(fmt.Sprintf("  %s: %s\n", param.label, value))
+			case param.key == "diskSelector":
+				sb.WriteString("  Disk Selector: any disk\n")
+			case param.key == "nodeSelector":
+				sb.WriteString("  Node Selector: any node\n")
+			case param.key == "migratable":
+				sb.WriteString("  Migratable: false\n")
+			}
+		}
+		if migratable, _ := parameters["migratable"].(string); migratable != "true" {
+			sb.WriteString("  Note: VMs using volumes of this class cannot be live migrated\n")
 		}
+	}
 
-		size := getNestedString(res.Object, "status", "size")
-		if size != "" {
-			sb.WriteString(fmt.Sprintf("  Size: %s\n", size))
+	// Remaining parameters
+	var keys []string
+	for key := range parameters {
+		if !shown[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	if len(keys) > 0 {
+		sb.WriteString("\nParameters:\n")
+		for _, key := range keys {
+			sb.WriteString(fmt.Sprintf("  %s: %v\n", key, parameters[key]))
 		}
 	}
 
@@ -445,53 +747,40 @@ func (f *VMImageFormatter) FormatResource(res *unstructured.Unstructured) string
 	return sb.String()
 }
 
-func (f *VMImageFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
+func (f *StorageClassFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
 	if len(list.Items) == 0 {
-		return "No VM images found in the specified namespace(s)."
+		return "No storage classes found."
 	}
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Found %d VM image(s):\n\n", len(list.Items)))
-
-	// Group images by namespace
-	imagesByNamespace := make(map[string][]unstructured.Unstructured)
-	for _, item := range list.Items {
-		namespace := item.GetNamespace()
-		imagesByNamespace[namespace] = append(imagesByNamespace[namespace], item)
-	}
+	sb.WriteString(fmt.Sprintf("Found %d storage class(es):\n\n", len(list.Items)))
 
-	// Print images grouped by namespace
-	for namespace, images := range imagesByNamespace {
-		sb.WriteString(fmt.Sprintf("Namespace: %s (%d images)\n", namespace, len(images)))
+	for _, storageClass := range list.Items {
+		if isDefaultStorageClass(&storageClass) {
+			sb.WriteString(fmt.Sprintf("• %s (default)\n", storageClass.GetName()))
+		} else {
+			sb.WriteString(fmt.Sprintf("• %s\n", storageClass.GetName()))
+		}
+		sb.WriteString(fmt.Sprintf("  Provisioner: %s\n", getNestedString(storageClass.Object, "provisioner")))
 
-		for _, image := range images {
-			// Get basic info
-			url := getNestedString(image.Object, "spec", "displayName")
-			if url == "" {
-				url = getNestedString(image.Object, "spec", "url")
+		if getNestedString(storageClass.Object, "provisioner") == longhornProvisioner {
+			parameters := getNestedMap(storageClass.Object, "parameters")
+			if replicas, _ := parameters["numberOfReplicas"].(string); replicas != "" {
+				sb.WriteString(fmt.Sprintf("  Replicas: %s\n", replicas))
 			}
-
-			size := getNestedString(image.Object, "status", "size")
-			progress := getNestedString(image.Object, "status", "progress")
-
-			// Basic image info
-			sb.WriteString(fmt.Sprintf("  • %s\n", image.GetName()))
-			if url != "" {
-				sb.WriteString(fmt.Sprintf("    Source: %s\n", url))
+			if diskSelector, _ := parameters["diskSelector"].(string); diskSelector != "" {
+				sb.WriteString(fmt.Sprintf("  Disk Selector: %s\n", diskSelector))
 			}
-			if size != "" {
-				sb.WriteString(fmt.Sprintf("    Size: %s\n", size))
+			if nodeSelector, _ := parameters["nodeSelector"].(string); nodeSelector != "" {
+				sb.WriteString(fmt.Sprintf("  Node Selector: %s\n", nodeSelector))
 			}
-			if progress != "" {
-				sb.WriteString(fmt.Sprintf("    Progress: %s\n", progress))
+			migratable, _ := parameters["migratable"].(string)
+			sb.WriteString(fmt.Sprintf("  Migratable: %t\n", migratable == "true"))
+			if backingImage, _ := parameters["backingImage"].(string); backingImage != "" {
+				sb.WriteString(fmt.Sprintf("  Backing Image: %s\n", backingImage))
 			}
-
-			// Creation time
-			creationTime := image.GetCreationTimestamp().Format(time.RFC3339)
-			sb.WriteString(fmt.Sprintf("    Created: %s\n", creationTime))
-
-			sb.WriteString("\n")
 		}
+		sb.WriteString(fmt.Sprintf("  Allow Volume Expansion: %t\n", getNestedBool(storageClass.Object, "allowVolumeExpansion")))
 
 		sb.WriteString("\n")
 	}
@@ -499,39 +788,987 @@ func (f *VMImageFormatter) FormatResourceList(list *unstructured.UnstructuredLis
 	return sb.String()
 }
 
-// CRDFormatter handles formatting for CustomResourceDefinition resources
-type CRDFormatter struct{}
+// NetworkFormatter handles formatting for VM networks, which are NetworkAttachmentDefinition resources
+type NetworkFormatter struct{}
 
-func (f *CRDFormatter) FormatResource(res *unstructured.Unstructured) string {
+func (f *NetworkFormatter) FormatResource(res *unstructured.Unstructured) string {
+	return FormatNetworkDetails(&NetworkDetails{Network: res})
+}
+
+// FormatNetworkDetails formats a VM network together with the VMs attached to it in a human-readable form
+func FormatNetworkDetails(network *NetworkDetails) string {
+	res := network.Network
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Custom Resource Definition: %s\n", res.GetName()))
+	labels := res.GetLabels()
+	sb.WriteString(fmt.Sprintf("Network: %s\n", res.GetName()))
+	sb.WriteString(fmt.Sprintf("Namespace: %s\n", res.GetNamespace()))
+	if networkType := labels[labelNetworkType]; networkType != "" {
+		sb.WriteString(fmt.Sprintf("Type: %s\n", networkType))
+	}
+	if clusterNetwork := labels[labelNetworkClusterNetwork]; clusterNetwork != "" {
+		sb.WriteString(fmt.Sprintf("Cluster Network: %s\n", clusterNetwork))
+	}
+	if ready, ok := labels[labelNetworkReady]; ok {
+		sb.WriteString(fmt.Sprintf("Ready: %s\n", ready))
+	}
 
-	// Get CRD details
-	group := getNestedString(res.Object, "spec", "group")
-	kind := getNestedString(res.Object, "spec", "names", "kind")
-	plural := getNestedString(res.Object, "spec", "names", "plural")
-	scope := getNestedString(res.Object, "spec", "scope")
+	// CNI config
+	config, err := parseNetworkConfig(res)
+	if err != nil {
+		sb.WriteString(fmt.Sprintf("Config: %v\n", err))
+	} else {
+		if config.VLAN > 0 {
+			sb.WriteString(fmt.Sprintf("VLAN ID: %d\n", config.VLAN))
+		} else {
+			sb.WriteString("VLAN ID: untagged\n")
+		}
+		if config.Bridge != "" {
+			sb.WriteString(fmt.Sprintf("Bridge: %s\n", config.Bridge))
+		}
+		sb.WriteString(fmt.Sprintf("CNI Type: %s\n", config.Type))
+		if config.CNIVersion != "" {
+			sb.WriteString(fmt.Sprintf("CNI Version: %s\n", config.CNIVersion))
+		}
+		if len(config.IPAM) > 0 {
+			sb.WriteString("\nIPAM:\n")
+			var keys []string
+			for key := range config.IPAM {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				value := config.IPAM[key]
+				if _, scalar := value.(string); !scalar {
+					if data, err := json.Marshal(value); err == nil {
+						value = string(data)
+					}
+				}
+				sb.WriteString(fmt.Sprintf("  %s: %v\n", key, value))
+			}
+		}
+	}
 
-	sb.WriteString(fmt.Sprintf("Group: %s\n", group))
-	sb.WriteString(fmt.Sprintf("Kind: %s\n", kind))
-	sb.WriteString(fmt.Sprintf("Plural: %s\n", plural))
-	sb.WriteString(fmt.Sprintf("Scope: %s\n", scope))
+	// Route
+	if route, err := parseNetworkRoute(res); err != nil {
+		sb.WriteString(fmt.Sprintf("\nRoute: %v\n", err))
+	} else if route != nil {
+		sb.WriteString("\nRoute:\n")
+		sb.WriteString(fmt.Sprintf("  Mode: %s\n", route.Mode))
+		if route.CIDR != "" {
+			sb.WriteString(fmt.Sprintf("  CIDR: %s\n", route.CIDR))
+		}
+		if route.Gateway != "" {
+			sb.WriteString(fmt.Sprintf("  Gateway: %s\n", route.Gateway))
+		}
+		if route.ServerIPAddr != "" {
+			sb.WriteString(fmt.Sprintf("  DHCP Server: %s\n", route.ServerIPAddr))
+		}
+		if route.Connectivity != "" {
+			sb.WriteString(fmt.Sprintf("  Connectivity: %s\n", route.Connectivity))
+		}
+	}
 
-	// Short names
-	shortNames, _, _ := unstructured.NestedStringSlice(res.Object, "spec", "names", "shortNames")
-	if len(shortNames) > 0 {
-		sb.WriteString("Short Names: ")
-		for i, name := range shortNames {
-			if i > 0 {
-				sb.WriteString(", ")
-			}
-			sb.WriteString(name)
+	// Virtual machines attached to the network
+	if len(network.VMs) > 0 {
+		sb.WriteString(fmt.Sprintf("\nUsed By VMs (%d):\n", len(network.VMs)))
+		for _, vm := range network.VMs {
+			sb.WriteString(fmt.Sprintf("  • %s\n", vm))
 		}
-		sb.WriteString("\n")
+	} else {
+		sb.WriteString("\nUsed By VMs: None\n")
 	}
 
-	// Versions
-	versions, _, _ := unstructured.NestedSlice(res.Object, "spec", "versions")
+	// Creation time
+	creationTime := res.GetCreationTimestamp().Format(time.RFC3339)
+	sb.WriteString(fmt.Sprintf("\nCreated: %s\n", creationTime))
+
+	return sb.String()
+}
+
+func (f *NetworkFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
+	networks := make([]NetworkDetails, 0, len(list.Items))
+	for i := range list.Items {
+		networks = append(networks, NetworkDetails{Network: &list.Items[i]})
+	}
+	return FormatNetworkDetailsList(networks)
+}
+
+// FormatNetworkDetailsList formats VM networks together with the VMs attached to them in a human-readable form
+func FormatNetworkDetailsList(details []NetworkDetails) string {
+	if len(details) == 0 {
+		return "No networks found in the specified namespace(s)."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d network(s):\n\n", len(details)))
+
+	// Group networks by namespace
+	networksByNamespace := make(map[string][]NetworkDetails)
+	for _, item := range details {
+		namespace := item.Network.GetNamespace()
+		networksByNamespace[namespace] = append(networksByNamespace[namespace], item)
+	}
+
+	// Print networks grouped by namespace
+	for namespace, networks := range networksByNamespace {
+		sb.WriteString(fmt.Sprintf("Namespace: %s (%d networks)\n", namespace, len(networks)))
+
+		for _, detail := range networks {
+			network := detail.Network
+			labels := network.GetLabels()
+
+			// Basic network info
+			sb.WriteString(fmt.Sprintf("  • %s\n", network.GetName()))
+			if networkType := labels[labelNetworkType]; networkType != "" {
+				sb.WriteString(fmt.Sprintf("    Type: %s\n", networkType))
+			}
+			if clusterNetwork := labels[labelNetworkClusterNetwork]; clusterNetwork != "" {
+				sb.WriteString(fmt.Sprintf("    Cluster Network: %s\n", clusterNetwork))
+			}
+
+			// VLAN and bridge from the CNI config
+			if config, err := parseNetworkConfig(network); err != nil {
+				sb.WriteString(fmt.Sprintf("    Config: %v\n", err))
+			} else {
+				if config.VLAN > 0 {
+					sb.WriteString(fmt.Sprintf("    VLAN ID: %d\n", config.VLAN))
+				} else {
+					sb.WriteString("    VLAN ID: untagged\n")
+				}
+				if config.Bridge != "" {
+					sb.WriteString(fmt.Sprintf("    Bridge: %s\n", config.Bridge))
+				}
+			}
+			if route, err := parseNetworkRoute(network); err == nil && route != nil && route.CIDR != "" {
+				sb.WriteString(fmt.Sprintf("    CIDR: %s (gateway %s)\n", route.CIDR, route.Gateway))
+			}
+			if len(detail.VMs) > 0 {
+				sb.WriteString(fmt.Sprintf("    VMs: %s\n", strings.Join(detail.VMs, ", ")))
+			}
+
+			// Creation time
+			creationTime := network.GetCreationTimestamp().Format(time.RFC3339)
+			sb.WriteString(fmt.Sprintf("    Created: %s\n", creationTime))
+
+			sb.WriteString("\n")
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// ClusterNetworkFormatter handles formatting for ClusterNetwork resources with their VLAN configs and per-node status
+type ClusterNetworkFormatter struct{}
+
+func (f *ClusterNetworkFormatter) FormatResource(res *unstructured.Unstructured) string {
+	return FormatClusterNetworkDetails(&ClusterNetworkDetails{ClusterNetwork: res})
+}
+
+// FormatClusterNetworkDetails formats a cluster network together with its VLAN configs and per-node status in a human-readable form
+func FormatClusterNetworkDetails(details *ClusterNetworkDetails) string {
+	res := details.ClusterNetwork
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Cluster Network: %s\n", res.GetName()))
+	sb.WriteString(fmt.Sprintf("Ready: %t\n", hasTrueCondition(res, "ready")))
+	sb.WriteString(fmt.Sprintf("Bridge: %s-br\n", res.GetName()))
+	if description := res.GetAnnotations()["field.cattle.io/description"]; description != "" {
+		sb.WriteString(fmt.Sprintf("Description: %s\n", description))
+	}
+	if res.GetName() == mgmtClusterNetwork {
+		sb.WriteString("Uplink: management interface configured at installation\n")
+	}
+
+	if notReady := notReadyVlanNodes(details.VlanStatuses); len(notReady) > 0 {
+		sb.WriteString(fmt.Sprintf("Not Ready Nodes: %s\n", strings.Join(notReady, ", ")))
+	}
+
+	// VLAN configs
+	if len(details.VlanConfigs) > 0 {
+		sb.WriteString(fmt.Sprintf("\nVLAN Configs (%d):\n", len(details.VlanConfigs)))
+		for _, vlanConfig := range details.VlanConfigs {
+			sb.WriteString(fmt.Sprintf("  • %s\n", vlanConfig.GetName()))
+			writeVlanConfigUplink(&sb, vlanConfig.Object, "    ")
+		}
+	} else if res.GetName() != mgmtClusterNetwork {
+		sb.WriteString("\nVLAN Configs: None (no node has an uplink on this cluster network)\n")
+	}
+
+	// Per-node status
+	if len(details.VlanStatuses) > 0 {
+		sb.WriteString(fmt.Sprintf("\nNodes (%d):\n", len(details.VlanStatuses)))
+		for _, vlanStatus := range details.VlanStatuses {
+			node := getNestedString(vlanStatus.Object, "status", "node")
+			vlanConfig := getNestedString(vlanStatus.Object, "status", "vlanConfig")
+			if ready, reason := vlanStatusReady(vlanStatus.Object); ready {
+				sb.WriteString(fmt.Sprintf("  • %s: ready (VLAN config %s)\n", node, vlanConfig))
+			} else {
+				sb.WriteString(fmt.Sprintf("  • %s: NOT READY (VLAN config %s): %s\n", node, vlanConfig, reason))
+			}
+		}
+	}
+
+	// Creation time
+	creationTime := res.GetCreationTimestamp().Format(time.RFC3339)
+	sb.WriteString(fmt.Sprintf("\nCreated: %s\n", creationTime))
+
+	return sb.String()
+}
+
+func (f *ClusterNetworkFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
+	clusterNetworks := make([]ClusterNetworkDetails, 0, len(list.Items))
+	for i := range list.Items {
+		clusterNetworks = append(clusterNetworks, ClusterNetworkDetails{ClusterNetwork: &list.Items[i]})
+	}
+	return FormatClusterNetworkDetailsList(clusterNetworks)
+}
+
+// FormatClusterNetworkDetailsList formats cluster networks together with their VLAN configs and per-node status in a human-readable form
+func FormatClusterNetworkDetailsList(details []ClusterNetworkDetails) string {
+	if len(details) == 0 {
+		return "No cluster networks found."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d cluster network(s):\n\n", len(details)))
+
+	for _, detail := range details {
+		clusterNetwork := detail.ClusterNetwork
+		sb.WriteString(fmt.Sprintf("• %s\n", clusterNetwork.GetName()))
+		sb.WriteString(fmt.Sprintf("  Ready: %t\n", hasTrueCondition(clusterNetwork, "ready")))
+		if clusterNetwork.GetName() == mgmtClusterNetwork {
+			sb.WriteString("  Uplink: management interface configured at installation\n")
+		}
+
+		// Uplinks of each VLAN config
+		for _, vlanConfig := range detail.VlanConfigs {
+			sb.WriteString(fmt.Sprintf("  Uplink %s: %s", vlanConfig.GetName(), vlanConfigUplinkSummary(vlanConfig.Object)))
+			if nodes := getNestedStringSlice(vlanConfig.Object, "status", "matchedNodes"); len(nodes) > 0 {
+				sb.WriteString(fmt.Sprintf(" on %s", strings.Join(nodes, ", ")))
+			}
+			sb.WriteString("\n")
+		}
+		if len(detail.VlanConfigs) == 0 && clusterNetwork.GetName() != mgmtClusterNetwork {
+			sb.WriteString("  Uplink: none configured\n")
+		}
+
+		if notReady := notReadyVlanNodes(detail.VlanStatuses); len(notReady) > 0 {
+			sb.WriteString(fmt.Sprintf("  Not Ready Nodes: %s\n", strings.Join(notReady, ", ")))
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// VlanConfigFormatter handles formatting for VlanConfig resources
+type VlanConfigFormatter struct{}
+
+func (f *VlanConfigFormatter) FormatResource(res *unstructured.Unstructured) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("VLAN Config: %s\n", res.GetName()))
+	sb.WriteString(fmt.Sprintf("Cluster Network: %s\n", getNestedString(res.Object, "spec", "clusterNetwork")))
+	if description := getNestedString(res.Object, "spec", "description"); description != "" {
+		sb.WriteString(fmt.Sprintf("Description: %s\n", description))
+	}
+	writeVlanConfigUplink(&sb, res.Object, "")
+
+	// Creation time
+	creationTime := res.GetCreationTimestamp().Format(time.RFC3339)
+	sb.WriteString(fmt.Sprintf("\nCreated: %s\n", creationTime))
+
+	return sb.String()
+}
+
+func (f *VlanConfigFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
+	if len(list.Items) == 0 {
+		return "No VLAN configs found."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d VLAN config(s):\n\n", len(list.Items)))
+
+	for _, vlanConfig := range list.Items {
+		sb.WriteString(fmt.Sprintf("• %s\n", vlanConfig.GetName()))
+		sb.WriteString(fmt.Sprintf("  Cluster Network: %s\n", getNestedString(vlanConfig.Object, "spec", "clusterNetwork")))
+		writeVlanConfigUplink(&sb, vlanConfig.Object, "  ")
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// VlanStatusFormatter handles formatting for the per-node VlanStatus resources
+type VlanStatusFormatter struct{}
+
+func (f *VlanStatusFormatter) FormatResource(res *unstructured.Unstructured) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("VLAN Status: %s\n", res.GetName()))
+	sb.WriteString(fmt.Sprintf("Node: %s\n", getNestedString(res.Object, "status", "node")))
+	sb.WriteString(fmt.Sprintf("Cluster Network: %s\n", getNestedString(res.Object, "status", "clusterNetwork")))
+	sb.WriteString(fmt.Sprintf("VLAN Config: %s\n", getNestedString(res.Object, "status", "vlanConfig")))
+	if ready, reason := vlanStatusReady(res.Object); ready {
+		sb.WriteString("Ready: true\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("Ready: false (%s)\n", reason))
+	}
+
+	// VLANs in use on the node
+	localAreas, _, _ := unstructured.NestedSlice(res.Object, "status", "localAreas")
+	if len(localAreas) > 0 {
+		sb.WriteString("\nVLANs:\n")
+		for _, areaObj := range localAreas {
+			area, ok := areaObj.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if cidr := getNestedString(area, "cidr"); cidr != "" {
+				sb.WriteString(fmt.Sprintf("  %d (%s)\n", getNestedInt64(area, "vid"), cidr))
+			} else {
+				sb.WriteString(fmt.Sprintf("  %d\n", getNestedInt64(area, "vid")))
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+func (f *VlanStatusFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
+	if len(list.Items) == 0 {
+		return "No VLAN statuses found."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d VLAN status(es):\n\n", len(list.Items)))
+
+	for _, vlanStatus := range list.Items {
+		sb.WriteString(fmt.Sprintf("• %s\n", getNestedString(vlanStatus.Object, "status", "node")))
+		sb.WriteString(fmt.Sprintf("  Cluster Network: %s\n", getNestedString(vlanStatus.Object, "status", "clusterNetwork")))
+		sb.WriteString(fmt.Sprintf("  VLAN Config: %s\n", getNestedString(vlanStatus.Object, "status", "vlanConfig")))
+		if ready, reason := vlanStatusReady(vlanStatus.Object); ready {
+			sb.WriteString("  Ready: true\n")
+		} else {
+			sb.WriteString(fmt.Sprintf("  Ready: false (%s)\n", reason))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// writeVlanConfigUplink writes the uplink NICs, bond options, MTU and node selection of a VLAN config.
+func writeVlanConfigUplink(sb *strings.Builder, vlanConfig map[string]interface{}, indent string) {
+	nics := getNestedStringSlice(vlanConfig, "spec", "uplink", "nics")
+	sb.WriteString(fmt.Sprintf("%sUplink NICs: %s\n", indent, strings.Join(nics, ", ")))
+	if mode := getNestedString(vlanConfig, "spec", "uplink", "bondOptions", "mode"); mode != "" {
+		sb.WriteString(fmt.Sprintf("%sBond Mode: %s\n", indent, mode))
+	}
+	if miimon := getNestedInt64(vlanConfig, "spec", "uplink", "bondOptions", "miimon"); miimon != 0 {
+		sb.WriteString(fmt.Sprintf("%sBond Miimon: %d\n", indent, miimon))
+	}
+	if mtu := getNestedInt64(vlanConfig, "spec", "uplink", "linkAttributes", "mtu"); mtu > 0 {
+		sb.WriteString(fmt.Sprintf("%sMTU: %d\n", indent, mtu))
+	}
+	if nodeSelector := getNestedMap(vlanConfig, "spec", "nodeSelector"); len(nodeSelector) > 0 {
+		var selectors []string
+		for key, value := range nodeSelector {
+			selectors = append(selectors, fmt.Sprintf("%s=%v", key, value))
+		}
+		sort.Strings(selectors)
+		sb.WriteString(fmt.Sprintf("%sNode Selector: %s\n", indent, strings.Join(selectors, ", ")))
+	} else {
+		sb.WriteString(fmt.Sprintf("%sNode Selector: all nodes\n", indent))
+	}
+	if nodes := getNestedStringSlice(vlanConfig, "status", "matchedNodes"); len(nodes) > 0 {
+		sb.WriteString(fmt.Sprintf("%sMatched Nodes: %s\n", indent, strings.Join(nodes, ", ")))
+	}
+}
+
+// vlanConfigUplinkSummary describes the uplink of a VLAN config on a single line.
+func vlanConfigUplinkSummary(vlanConfig map[string]interface{}) string {
+	summary := strings.Join(getNestedStringSlice(vlanConfig, "spec", "uplink", "nics"), ", ")
+	var details []string
+	if mode := getNestedString(vlanConfig, "spec", "uplink", "bondOptions", "mode"); mode != "" {
+		details = append(details, mode)
+	}
+	if mtu := getNestedInt64(vlanConfig, "spec", "uplink", "linkAttributes", "mtu"); mtu > 0 {
+		details = append(details, fmt.Sprintf("MTU %d", mtu))
+	}
+	if len(details) > 0 {
+		summary += fmt.Sprintf(" (%s)", strings.Join(details, ", "))
+	}
+	return summary
+}
+
+// notReadyVlanNodes returns the nodes of the given VlanStatuses whose VLAN setup is not ready.
+func notReadyVlanNodes(vlanStatuses []unstructured.Unstructured) []string {
+	var nodes []string
+	for _, vlanStatus := range vlanStatuses {
+		if ready, _ := vlanStatusReady(vlanStatus.Object); !ready {
+			nodes = append(nodes, getNestedString(vlanStatus.Object, "status", "node"))
+		}
+	}
+	return nodes
+}
+
+// LoadBalancerFormatter handles formatting for Harvester LoadBalancer resources
+type LoadBalancerFormatter struct{}
+
+func (f *LoadBalancerFormatter) FormatResource(res *unstructured.Unstructured) string {
+	return FormatLoadBalancerDetails(&LoadBalancerDetails{LoadBalancer: res})
+}
+
+// FormatLoadBalancerDetails formats a load balancer together with its backend VMs in a human-readable form
+func FormatLoadBalancerDetails(details *LoadBalancerDetails) string {
+	res := details.LoadBalancer
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Load Balancer: %s\n", res.GetName()))
+	sb.WriteString(fmt.Sprintf("Namespace: %s\n", res.GetNamespace()))
+	if description := getNestedString(res.Object, "spec", "description"); description != "" {
+		sb.WriteString(fmt.Sprintf("Description: %s\n", description))
+	}
+	sb.WriteString(fmt.Sprintf("Workload Type: %s\n", getNestedString(res.Object, "spec", "workloadType")))
+	sb.WriteString(fmt.Sprintf("IPAM: %s\n", loadBalancerIPAM(res.Object)))
+	sb.WriteString(fmt.Sprintf("Ready: %t\n", hasTrueCondition(res, "Ready")))
+
+	// Allocated address
+	if address := getNestedString(res.Object, "status", "address"); address != "" {
+		sb.WriteString(fmt.Sprintf("Address: %s\n", address))
+	} else {
+		sb.WriteString("Address: not allocated yet\n")
+	}
+	if allocated := getNestedMap(res.Object, "status", "allocatedAddress"); len(allocated) > 0 {
+		sb.WriteString("\nAllocated Address:\n")
+		if ip := getNestedString(allocated, "ip"); ip != "" {
+			sb.WriteString(fmt.Sprintf("  IP: %s\n", ip))
+		}
+		if mask := getNestedString(allocated, "mask"); mask != "" {
+			sb.WriteString(fmt.Sprintf("  Mask: %s\n", mask))
+		}
+		if gateway := getNestedString(allocated, "gateway"); gateway != "" {
+			sb.WriteString(fmt.Sprintf("  Gateway: %s\n", gateway))
+		}
+		if pool := getNestedString(allocated, "ipPool"); pool != "" {
+			sb.WriteString(fmt.Sprintf("  IP Pool: %s\n", pool))
+		}
+	}
+
+	// Listeners
+	if listeners := loadBalancerListeners(res.Object); len(listeners) > 0 {
+		sb.WriteString("\nListeners:\n")
+		for _, listener := range listeners {
+			sb.WriteString(fmt.Sprintf("  • %s\n", listener))
+		}
+	}
+
+	// Backends
+	if selector := loadBalancerSelector(res.Object); selector != "" {
+		sb.WriteString(fmt.Sprintf("\nBackend Selector: %s\n", selector))
+	}
+	if len(details.BackendVMs) > 0 {
+		sb.WriteString("Backend VMs:\n")
+		for _, vm := range details.BackendVMs {
+			sb.WriteString(fmt.Sprintf("  • %s\n", vm))
+		}
+	} else {
+		sb.WriteString("Backend VMs: None (no running VM matches the selector)\n")
+	}
+	if servers := getNestedStringSlice(res.Object, "status", "backendServers"); len(servers) > 0 {
+		sb.WriteString(fmt.Sprintf("Backend Servers: %s\n", strings.Join(servers, ", ")))
+	}
+
+	// Conditions that are not satisfied
+	conditions, _, _ := unstructured.NestedSlice(res.Object, "status", "conditions")
+	for _, condObj := range conditions {
+		cond, ok := condObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if getNestedString(cond, "status") == "False" {
+			sb.WriteString(fmt.Sprintf("\n%s: %s\n", getNestedString(cond, "type"), getNestedString(cond, "message")))
+		}
+	}
+
+	// Creation time
+	creationTime := res.GetCreationTimestamp().Format(time.RFC3339)
+	sb.WriteString(fmt.Sprintf("\nCreated: %s\n", creationTime))
+
+	return sb.String()
+}
+
+func (f *LoadBalancerFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
+	lbs := make([]LoadBalancerDetails, 0, len(list.Items))
+	for i := range list.Items {
+		lbs = append(lbs, LoadBalancerDetails{LoadBalancer: &list.Items[i]})
+	}
+	return FormatLoadBalancerDetailsList(lbs)
+}
+
+// FormatLoadBalancerDetailsList formats load balancers together with their backend VMs in a human-readable form
+func FormatLoadBalancerDetailsList(details []LoadBalancerDetails) string {
+	if len(details) == 0 {
+		return "No load balancers found in the specified namespace(s)."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d load balancer(s):\n\n", len(details)))
+
+	// Group load balancers by namespace
+	lbsByNamespace := make(map[string][]LoadBalancerDetails)
+	for _, item := range details {
+		namespace := item.LoadBalancer.GetNamespace()
+		lbsByNamespace[namespace] = append(lbsByNamespace[namespace], item)
+	}
+
+	// Print load balancers grouped by namespace
+	for namespace, lbs := range lbsByNamespace {
+		sb.WriteString(fmt.Sprintf("Namespace: %s (%d load balancers)\n", namespace, len(lbs)))
+
+		for _, detail := range lbs {
+			lb := detail.LoadBalancer
+			sb.WriteString(fmt.Sprintf("  • %s\n", lb.GetName()))
+			if address := getNestedString(lb.Object, "status", "address"); address != "" {
+				sb.WriteString(fmt.Sprintf("    Address: %s\n", address))
+			} else {
+				sb.WriteString("    Address: not allocated yet\n")
+			}
+			sb.WriteString(fmt.Sprintf("    IPAM: %s\n", loadBalancerIPAM(lb.Object)))
+			if listeners := loadBalancerListeners(lb.Object); len(listeners) > 0 {
+				sb.WriteString(fmt.Sprintf("    Listeners: %s\n", strings.Join(listeners, ", ")))
+			}
+			if selector := loadBalancerSelector(lb.Object); selector != "" {
+				sb.WriteString(fmt.Sprintf("    Backend Selector: %s\n", selector))
+			}
+			if len(detail.BackendVMs) > 0 {
+				sb.WriteString(fmt.Sprintf("    Backend VMs: %s\n", strings.Join(detail.BackendVMs, ", ")))
+			}
+
+			// Creation time
+			creationTime := lb.GetCreationTimestamp().Format(time.RFC3339)
+			sb.WriteString(fmt.Sprintf("    Created: %s\n", creationTime))
+
+			sb.WriteString("\n")
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// loadBalancerIPAM describes how the address of a load balancer is allocated.
+func loadBalancerIPAM(lb map[string]interface{}) string {
+	ipam := getNestedString(lb, "spec", "ipam")
+	if pool := getNestedString(lb, "spec", "ipPool"); pool != "" {
+		return fmt.Sprintf("%s (%s)", ipam, pool)
+	}
+	return ipam
+}
+
+// loadBalancerListeners describes each listener of a load balancer as "name: port/protocol -> backendPort".
+func loadBalancerListeners(lb map[string]interface{}) []string {
+	var listeners []string
+	listenerObjs, _, _ := unstructured.NestedSlice(lb, "spec", "listeners")
+	for _, listenerObj := range listenerObjs {
+		listener, ok := listenerObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		listeners = append(listeners, fmt.Sprintf("%s: %d/%s -> %d",
+			getNestedString(listener, "name"),
+			getNestedInt64(listener, "port"),
+			getNestedString(listener, "protocol"),
+			getNestedInt64(listener, "backendPort")))
+	}
+	return listeners
+}
+
+// loadBalancerSelector describes the backend server selector of a load balancer as "key=value1|value2" terms.
+func loadBalancerSelector(lb map[string]interface{}) string {
+	var terms []string
+	for key, valuesObj := range getNestedMap(lb, "spec", "backendServerSelector") {
+		var values []string
+		if valueObjs, ok := valuesObj.([]interface{}); ok {
+			for _, value := range valueObjs {
+				values = append(values, fmt.Sprintf("%v", value))
+			}
+		}
+		terms = append(terms, fmt.Sprintf("%s=%s", key, strings.Join(values, "|")))
+	}
+	sort.Strings(terms)
+	return strings.Join(terms, ", ")
+}
+
+// IPPoolFormatter handles formatting for Harvester load balancer IPPool resources
+type IPPoolFormatter struct{}
+
+func (f *IPPoolFormatter) FormatResource(res *unstructured.Unstructured) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("IP Pool: %s\n", res.GetName()))
+	if description := getNestedString(res.Object, "spec", "description"); description != "" {
+		sb.WriteString(fmt.Sprintf("Description: %s\n", description))
+	}
+	total, used, free := ipPoolUsage(res.Object)
+	sb.WriteString(fmt.Sprintf("Addresses: %d total, %d used, %d free\n", total, used, free))
+	if lastAllocated := getNestedString(res.Object, "status", "lastAllocated"); lastAllocated != "" {
+		sb.WriteString(fmt.Sprintf("Last Allocated: %s\n", lastAllocated))
+	}
+
+	// Ranges
+	if ranges := ipPoolRanges(res.Object); len(ranges) > 0 {
+		sb.WriteString("\nRanges:\n")
+		for _, r := range ranges {
+			sb.WriteString(fmt.Sprintf("  • %s\n", r))
+		}
+	}
+
+	// Selector deciding which load balancers use the pool
+	selector := getNestedMap(res.Object, "spec", "selector")
+	if len(selector) > 0 {
+		sb.WriteString("\nSelector:\n")
+		if network := getNestedString(selector, "network"); network != "" {
+			sb.WriteString(fmt.Sprintf("  Network: %s\n", network))
+		}
+		if priority := getNestedInt64(selector, "priority"); priority != 0 {
+			sb.WriteString(fmt.Sprintf("  Priority: %d\n", priority))
+		}
+		scopes, _, _ := unstructured.NestedSlice(selector, "scope")
+		for _, scopeObj := range scopes {
+			scope, ok := scopeObj.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("  Scope: project %s, namespace %s, guest cluster %s\n",
+				getNestedString(scope, "project"), getNestedString(scope, "namespace"), getNestedString(scope, "guestCluster")))
+		}
+	}
+
+	// Allocated addresses
+	allocated := getNestedMap(res.Object, "status", "allocated")
+	if len(allocated) > 0 {
+		var ips []string
+		for ip := range allocated {
+			ips = append(ips, ip)
+		}
+		sort.Strings(ips)
+		sb.WriteString("\nAllocated:\n")
+		for _, ip := range ips {
+			sb.WriteString(fmt.Sprintf("  • %s: %v\n", ip, allocated[ip]))
+		}
+	}
+
+	// Creation time
+	creationTime := res.GetCreationTimestamp().Format(time.RFC3339)
+	sb.WriteString(fmt.Sprintf("\nCreated: %s\n", creationTime))
+
+	return sb.String()
+}
+
+func (f *IPPoolFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
+	if len(list.Items) == 0 {
+		return "No IP pools found."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d IP pool(s):\n\n", len(list.Items)))
+
+	for _, pool := range list.Items {
+		total, used, free := ipPoolUsage(pool.Object)
+		sb.WriteString(fmt.Sprintf("• %s\n", pool.GetName()))
+		sb.WriteString(fmt.Sprintf("  Addresses: %d total, %d used, %d free\n", total, used, free))
+		if ranges := ipPoolRanges(pool.Object); len(ranges) > 0 {
+			sb.WriteString(fmt.Sprintf("  Ranges: %s\n", strings.Join(ranges, "; ")))
+		}
+		if network := getNestedString(pool.Object, "spec", "selector", "network"); network != "" {
+			sb.WriteString(fmt.Sprintf("  Network: %s\n", network))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// ipPoolUsage returns the total, used and free address counts of an IP pool.
+func ipPoolUsage(pool map[string]interface{}) (int64, int64, int64) {
+	total := getNestedInt64(pool, "status", "total")
+	free := getNestedInt64(pool, "status", "available")
+	used := int64(len(getNestedMap(pool, "status", "allocated")))
+	if total > 0 {
+		used = total - free
+	}
+	return total, used, free
+}
+
+// ipPoolRanges describes each range of an IP pool as "subnet (start - end, gateway gw)".
+func ipPoolRanges(pool map[string]interface{}) []string {
+	var ranges []string
+	rangeObjs, _, _ := unstructured.NestedSlice(pool, "spec", "ranges")
+	for _, rangeObj := range rangeObjs {
+		r, ok := rangeObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var details []string
+		start, end := getNestedString(r, "rangeStart"), getNestedString(r, "rangeEnd")
+		if start != "" || end != "" {
+			details = append(details, fmt.Sprintf("%s - %s", start, end))
+		}
+		if gateway := getNestedString(r, "gateway"); gateway != "" {
+			details = append(details, fmt.Sprintf("gateway %s", gateway))
+		}
+		description := getNestedString(r, "subnet")
+		if len(details) > 0 {
+			description += fmt.Sprintf(" (%s)", strings.Join(details, ", "))
+		}
+		ranges = append(ranges, description)
+	}
+	return ranges
+}
+
+// VMImageFormatter handles formatting for VirtualMachineImage resources
+type VMImageFormatter struct{}
+
+func (f *VMImageFormatter) FormatResource(res *unstructured.Unstructured) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("VM Image: %s\n", res.GetName()))
+	sb.WriteString(fmt.Sprintf("Namespace: %s\n", res.GetNamespace()))
+
+	// Get image details
+	displayName := getNestedString(res.Object, "spec", "displayName")
+	sourceType := getNestedString(res.Object, "spec", "sourceType")
+	url := getNestedString(res.Object, "spec", "url")
+	checksum := getNestedString(res.Object, "spec", "checksum")
+	description := getNestedString(res.Object, "spec", "description")
+
+	if displayName != "" {
+		sb.WriteString(fmt.Sprintf("Display Name: %s\n", displayName))
+	}
+	if sourceType != "" {
+		sb.WriteString(fmt.Sprintf("Source Type: %s\n", sourceType))
+	}
+	if url != "" {
+		sb.WriteString(fmt.Sprintf("URL: %s\n", url))
+	}
+	if sourceType == ImageSourceTypeExportVolume {
+		sb.WriteString(fmt.Sprintf("Source Volume: %s/%s\n", getNestedString(res.Object, "spec", "pvcNamespace"), getNestedString(res.Object, "spec", "pvcName")))
+	}
+	if checksum != "" {
+		sb.WriteString(fmt.Sprintf("Checksum: %s\n", checksum))
+	}
+	if description != "" {
+		sb.WriteString(fmt.Sprintf("Description: %s\n", description))
+	}
+
+	// Storage class and the parameters of the backing image
+	storageClass := getNestedString(res.Object, "status", "storageClassName")
+	if storageClass == "" {
+		storageClass = res.GetAnnotations()[annotationImageStorageClass]
+	}
+	if storageClass != "" {
+		sb.WriteString(fmt.Sprintf("Storage Class: %s\n", storageClass))
+	}
+	parameters := getNestedMap(res.Object, "spec", "storageClassParameters")
+	if len(parameters) > 0 {
+		keys := make([]string, 0, len(parameters))
+		for key := range parameters {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		sb.WriteString("Storage Class Parameters:\n")
+		for _, key := range keys {
+			sb.WriteString(fmt.Sprintf("  %s: %v\n", key, parameters[key]))
+		}
+	}
+
+	// Status details
+	status, statusFound, _ := unstructured.NestedMap(res.Object, "status")
+	if statusFound && len(status) > 0 {
+		sb.WriteString("\nStatus:\n")
+		sb.WriteString(fmt.Sprintf("  State: %s\n", imageState(res)))
+		sb.WriteString(fmt.Sprintf("  Progress: %d%%\n", getNestedInt64(res.Object, "status", "progress")))
+
+		if size := getNestedInt64(res.Object, "status", "size"); size > 0 {
+			sb.WriteString(fmt.Sprintf("  Size: %s\n", resource.NewQuantity(size, resource.BinarySI).String()))
+		}
+		if virtualSize := getNestedInt64(res.Object, "status", "virtualSize"); virtualSize > 0 {
+			sb.WriteString(fmt.Sprintf("  Virtual Size: %s\n", resource.NewQuantity(virtualSize, resource.BinarySI).String()))
+		}
+		if failed := getNestedInt64(res.Object, "status", "failed"); failed > 0 {
+			sb.WriteString(fmt.Sprintf("  Failed Attempts: %d\n", failed))
+		}
+	}
+
+	// Failure conditions
+	conditions, _, _ := unstructured.NestedSlice(res.Object, "status", "conditions")
+	var failures []string
+	for _, condObj := range conditions {
+		cond, ok := condObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		condType := getNestedString(cond, "type")
+		condStatus := getNestedString(cond, "status")
+		if (condType == "RetryLimitExceeded" && condStatus == "True") || (condStatus == "False" && getNestedString(cond, "message") != "") {
+			failures = append(failures, fmt.Sprintf("  %s: %s", condType, getNestedString(cond, "message")))
+		}
+	}
+	if len(failures) > 0 {
+		sb.WriteString("\nFailures:\n")
+		sb.WriteString(strings.Join(failures, "\n"))
+		sb.WriteString("\n")
+	}
+
+	// Creation time
+	creationTime := res.GetCreationTimestamp().Format(time.RFC3339)
+	sb.WriteString(fmt.Sprintf("\nCreated: %s\n", creationTime))
+
+	return sb.String()
+}
+
+func (f *VMImageFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
+	if len(list.Items) == 0 {
+		return "No VM images found in the specified namespace(s)."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d VM image(s):\n\n", len(list.Items)))
+
+	// Group images by namespace
+	imagesByNamespace := make(map[string][]unstructured.Unstructured)
+	for _, item := range list.Items {
+		namespace := item.GetNamespace()
+		imagesByNamespace[namespace] = append(imagesByNamespace[namespace], item)
+	}
+
+	// Print images grouped by namespace
+	for namespace, images := range imagesByNamespace {
+		sb.WriteString(fmt.Sprintf("Namespace: %s (%d images)\n", namespace, len(images)))
+
+		for _, image := range images {
+			// Get basic info
+			url := getNestedString(image.Object, "spec", "displayName")
+			if url == "" {
+				url = getNestedString(image.Object, "spec", "url")
+			}
+
+			size := getNestedInt64(image.Object, "status", "size")
+			progress := getNestedInt64(image.Object, "status", "progress")
+
+			// Basic image info
+			sb.WriteString(fmt.Sprintf("  • %s\n", image.GetName()))
+			if url != "" {
+				sb.WriteString(fmt.Sprintf("    Source: %s\n", url))
+			}
+			sb.WriteString(fmt.Sprintf("    State: %s\n", imageState(&image)))
+			if size > 0 {
+				sb.WriteString(fmt.Sprintf("    Size: %s\n", resource.NewQuantity(size, resource.BinarySI).String()))
+			}
+			sb.WriteString(fmt.Sprintf("    Progress: %d%%\n", progress))
+
+			// Creation time
+			creationTime := image.GetCreationTimestamp().Format(time.RFC3339)
+			sb.WriteString(fmt.Sprintf("    Created: %s\n", creationTime))
+
+			sb.WriteString("\n")
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// imageFailureMessage returns why the import of a VirtualMachineImage failed, preferring the message of the
+// RetryLimitExceeded condition over that of the last failed attempt.
+func imageFailureMessage(res *unstructured.Unstructured) string {
+	var message string
+	conditions, _, _ := unstructured.NestedSlice(res.Object, "status", "conditions")
+	for _, condObj := range conditions {
+		cond, ok := condObj.(map[string]interface{})
+		if !ok || getNestedString(cond, "message") == "" {
+			continue
+		}
+
+		switch getNestedString(cond, "type") {
+		case "RetryLimitExceeded":
+			return getNestedString(cond, "message")
+		case "Imported":
+			message = getNestedString(cond, "message")
+		}
+	}
+	return message
+}
+
+// imageState summarizes the import state of a VirtualMachineImage from its conditions and progress.
+func imageState(res *unstructured.Unstructured) string {
+	conditions, _, _ := unstructured.NestedSlice(res.Object, "status", "conditions")
+	for _, condObj := range conditions {
+		cond, ok := condObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		condType := getNestedString(cond, "type")
+		condStatus := getNestedString(cond, "status")
+		// Imported=False only reports a failed attempt while Harvester keeps retrying
+		switch {
+		case condType == "RetryLimitExceeded" && condStatus == "True":
+			return "Failed"
+		case condType == "Imported" && condStatus == "True":
+			return "Active"
+		}
+	}
+
+	if getNestedInt64(res.Object, "status", "progress") > 0 {
+		switch getNestedString(res.Object, "spec", "sourceType") {
+		case ImageSourceTypeUpload:
+			return "Uploading"
+		case ImageSourceTypeExportVolume:
+			return "Exporting"
+		default:
+			return "Downloading"
+		}
+	}
+	return "Pending"
+}
+
+// CRDFormatter handles formatting for CustomResourceDefinition resources
+type CRDFormatter struct{}
+
+func (f *CRDFormatter) FormatResource(res *unstructured.Unstructured) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Custom Resource Definition: %s\n", res.GetName()))
+
+	// Get CRD details
+	group := getNestedString(res.Object, "spec", "group")
+	kind := getNestedString(res.Object, "spec", "names", "kind")
+	plural := getNestedString(res.Object, "spec", "names", "plural")
+	scope := getNestedString(res.Object, "spec", "scope")
+
+	sb.WriteString(fmt.Sprintf("Group: %s\n", group))
+	sb.WriteString(fmt.Sprintf("Kind: %s\n", kind))
+	sb.WriteString(fmt.Sprintf("Plural: %s\n", plural))
+	sb.WriteString(fmt.Sprintf("Scope: %s\n", scope))
+
+	// Short names
+	shortNames, _, _ := unstructured.NestedStringSlice(res.Object, "spec", "names", "shortNames")
+	if len(shortNames) > 0 {
+		sb.WriteString("Short Names: ")
+		for i, name := range shortNames {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(name)
+		}
+		sb.WriteString("\n")
+	}
+
+	// Versions
+	versions, _, _ := unstructured.NestedSlice(res.Object, "spec", "versions")
 	if len(versions) > 0 {
 		sb.WriteString("\nVersions:\n")
 		for _, versionObj := range versions {
@@ -540,22 +1777,796 @@ func (f *CRDFormatter) FormatResource(res *unstructured.Unstructured) string {
 				continue
 			}
 
-			name, _, _ := unstructured.NestedString(version, "name")
-			served, _, _ := unstructured.NestedBool(version, "served")
-			storage, _, _ := unstructured.NestedBool(version, "storage")
+			name, _, _ := unstructured.NestedString(version, "name")
+			served, _, _ := unstructured.NestedBool(version, "served")
+			storage, _, _ := unstructured.NestedBool(version, "storage")
+
+			sb.WriteString(fmt.Sprintf("  %s:\n", name))
+			sb.WriteString(fmt.Sprintf("    Served: %t\n", served))
+			sb.WriteString(fmt.Sprintf("    Storage: %t\n", storage))
+
+			// Schema details if present
+			schema, schemaFound, _ := unstructured.NestedMap(version, "schema", "openAPIV3Schema")
+			if schemaFound && len(schema) > 0 {
+				sb.WriteString("    Schema: Available\n")
+			}
+		}
+	}
+
+	// Creation time
+	creationTime := res.GetCreationTimestamp().Format(time.RFC3339)
+	sb.WriteString(fmt.Sprintf("\nCreated: %s\n", creationTime))
+
+	return sb.String()
+}
+
+func (f *CRDFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
+	if len(list.Items) == 0 {
+		return "No custom resource definitions found."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d custom resource definition(s):\n\n", len(list.Items)))
+
+	// Group CRDs by group
+	crdsByGroup := make(map[string][]unstructured.Unstructured)
+	for _, item := range list.Items {
+		group := getNestedString(item.Object, "spec", "group")
+		if group == "" {
+			group = "core"
+		}
+		crdsByGroup[group] = append(crdsByGroup[group], item)
+	}
+
+	// Print CRDs grouped by group
+	for group, crds := range crdsByGroup {
+		sb.WriteString(fmt.Sprintf("Group: %s (%d CRDs)\n", group, len(crds)))
+
+		for _, crd := range crds {
+			// Get basic info
+			kind := getNestedString(crd.Object, "spec", "names", "kind")
+			plural := getNestedString(crd.Object, "spec", "names", "plural")
+
+			// Basic CRD info
+			sb.WriteString(fmt.Sprintf("  • %s\n", crd.GetName()))
+			sb.WriteString(fmt.Sprintf("    Kind: %s\n", kind))
+			sb.WriteString(fmt.Sprintf("    Plural: %s\n", plural))
+
+			// Versions
+			versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+			if len(versions) > 0 {
+				sb.WriteString("    Versions:\n")
+				for _, versionObj := range versions {
+					version, ok := versionObj.(map[string]interface{})
+					if !ok {
+						continue
+					}
+
+					name, _, _ := unstructured.NestedString(version, "name")
+					served, _, _ := unstructured.NestedBool(version, "served")
+					storage, _, _ := unstructured.NestedBool(version, "storage")
+
+					sb.WriteString(fmt.Sprintf("      %s (served: %t, storage: %t)\n", name, served, storage))
+				}
+			}
+
+			// Creation time
+			creationTime := crd.GetCreationTimestamp().Format(time.RFC3339)
+			sb.WriteString(fmt.Sprintf("    Created: %s\n", creationTime))
+
+			sb.WriteString("\n")
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// VMMigrationFormatter handles formatting for VirtualMachineInstanceMigration resources
+type VMMigrationFormatter struct{}
+
+func (f *VMMigrationFormatter) FormatResource(res *unstructured.Unstructured) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("VM Migration: %s\n", res.GetName()))
+	sb.WriteString(fmt.Sprintf("Namespace: %s\n", res.GetNamespace()))
+	sb.WriteString(fmt.Sprintf("Virtual Machine: %s\n", getNestedString(res.Object, "spec", "vmiName")))
+
+	phase := getNestedString(res.Object, "status", "phase")
+	if phase == "" {
+		phase = "Pending"
+	}
+	sb.WriteString(fmt.Sprintf("Phase: %s\n", phase))
+
+	// Source and target nodes
+	sourceNode := getNestedString(res.Object, "status", "migrationState", "sourceNode")
+	targetNode := getNestedString(res.Object, "status", "migrationState", "targetNode")
+	if sourceNode != "" {
+		sb.WriteString(fmt.Sprintf("Source Node: %s\n", sourceNode))
+	}
+	if targetNode != "" {
+		sb.WriteString(fmt.Sprintf("Target Node: %s\n", targetNode))
+	}
+
+	// Timing
+	if startTime := getNestedString(res.Object, "status", "migrationState", "startTimestamp"); startTime != "" {
+		sb.WriteString(fmt.Sprintf("Started: %s\n", startTime))
+	}
+	if endTime := getNestedString(res.Object, "status", "migrationState", "endTimestamp"); endTime != "" {
+		sb.WriteString(fmt.Sprintf("Ended: %s\n", endTime))
+	}
+	sb.WriteString(fmt.Sprintf("Elapsed: %s\n", migrationElapsed(res)))
+
+	// Failure and abort details
+	if getNestedBool(res.Object, "status", "migrationState", "failed") {
+		sb.WriteString("Failed: true\n")
+		if reason := getNestedString(res.Object, "status", "migrationState", "failureReason"); reason != "" {
+			sb.WriteString(fmt.Sprintf("Failure Reason: %s\n", reason))
+		}
+	}
+	if abortStatus := getNestedString(res.Object, "status", "migrationState", "abortStatus"); abortStatus != "" {
+		sb.WriteString(fmt.Sprintf("Abort Status: %s\n", abortStatus))
+	}
+
+	// Creation time
+	creationTime := res.GetCreationTimestamp().Format(time.RFC3339)
+	sb.WriteString(fmt.Sprintf("\nCreated: %s\n", creationTime))
+
+	return sb.String()
+}
+
+func (f *VMMigrationFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
+	if len(list.Items) == 0 {
+		return "No VM migrations found in the specified namespace(s)."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d VM migration(s):\n\n", len(list.Items)))
+
+	// Group migrations by namespace
+	migrationsByNamespace := make(map[string][]unstructured.Unstructured)
+	for _, item := range list.Items {
+		namespace := item.GetNamespace()
+		migrationsByNamespace[namespace] = append(migrationsByNamespace[namespace], item)
+	}
+
+	// Print migrations grouped by namespace
+	for namespace, migrations := range migrationsByNamespace {
+		sb.WriteString(fmt.Sprintf("Namespace: %s (%d migrations)\n", namespace, len(migrations)))
+
+		for _, migration := range migrations {
+			phase := getNestedString(migration.Object, "status", "phase")
+			if phase == "" {
+				phase = "Pending"
+			}
+			sourceNode := getNestedString(migration.Object, "status", "migrationState", "sourceNode")
+			targetNode := getNestedString(migration.Object, "status", "migrationState", "targetNode")
+
+			// Basic migration info
+			sb.WriteString(fmt.Sprintf("  • %s\n", migration.GetName()))
+			sb.WriteString(fmt.Sprintf("    VM: %s\n", getNestedString(migration.Object, "spec", "vmiName")))
+			sb.WriteString(fmt.Sprintf("    Phase: %s\n", phase))
+			if sourceNode != "" || targetNode != "" {
+				sb.WriteString(fmt.Sprintf("    Nodes: %s -> %s\n", sourceNode, targetNode))
+			}
+			sb.WriteString(fmt.Sprintf("    Elapsed: %s\n", migrationElapsed(&migration)))
+
+			// Creation time
+			creationTime := migration.GetCreationTimestamp().Format(time.RFC3339)
+			sb.WriteString(fmt.Sprintf("    Created: %s\n", creationTime))
+
+			sb.WriteString("\n")
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// migrationElapsed returns how long a migration has been running, or how long it took if it finished.
+func migrationElapsed(res *unstructured.Unstructured) time.Duration {
+	start := res.GetCreationTimestamp().Time
+	if startTime, err := time.Parse(time.RFC3339, getNestedString(res.Object, "status", "migrationState", "startTimestamp")); err == nil {
+		start = startTime
+	}
+
+	end := time.Now()
+	if endTime, err := time.Parse(time.RFC3339, getNestedString(res.Object, "status", "migrationState", "endTimestamp")); err == nil {
+		end = endTime
+	} else if phase := getNestedString(res.Object, "status", "phase"); phase == "Succeeded" || phase == "Failed" {
+		// Fall back to the time the final phase was reached
+		transitions, _, _ := unstructured.NestedSlice(res.Object, "status", "phaseTransitionTimestamps")
+		for _, transitionObj := range transitions {
+			transition, ok := transitionObj.(map[string]interface{})
+			if !ok || getNestedString(transition, "phase") != phase {
+				continue
+			}
+			if transitionTime, err := time.Parse(time.RFC3339, getNestedString(transition, "phaseTransitionTimestamp")); err == nil {
+				end = transitionTime
+			}
+		}
+	}
+
+	if start.IsZero() || end.Before(start) {
+		return 0
+	}
+	return end.Sub(start).Round(time.Second)
+}
+
+// VMBackupFormatter handles formatting for VirtualMachineBackup resources, covering both snapshots and backups
+type VMBackupFormatter struct{}
+
+func (f *VMBackupFormatter) FormatResource(res *unstructured.Unstructured) string {
+	var sb strings.Builder
+	backupType := vmBackupType(res)
+	if backupType == VMBackupTypeSnapshot {
+		sb.WriteString(fmt.Sprintf("VM Snapshot: %s\n", res.GetName()))
+	} else {
+		sb.WriteString(fmt.Sprintf("VM Backup: %s\n", res.GetName()))
+	}
+	sb.WriteString(fmt.Sprintf("Namespace: %s\n", res.GetNamespace()))
+	sb.WriteString(fmt.Sprintf("Source VM: %s\n", getNestedString(res.Object, "spec", "source", "name")))
+	sb.WriteString(fmt.Sprintf("Ready To Use: %t\n", getNestedBool(res.Object, "status", "readyToUse")))
+
+	// Backup target and progress only apply to backups
+	if backupType == VMBackupTypeBackup {
+		if endpoint := getNestedString(res.Object, "status", "backupTarget", "endpoint"); endpoint != "" {
+			sb.WriteString(fmt.Sprintf("Backup Target: %s\n", endpoint))
+		}
+		if bucket := getNestedString(res.Object, "status", "backupTarget", "bucketName"); bucket != "" {
+			sb.WriteString(fmt.Sprintf("Bucket: %s\n", bucket))
+		}
+		if region := getNestedString(res.Object, "status", "backupTarget", "bucketRegion"); region != "" {
+			sb.WriteString(fmt.Sprintf("Region: %s\n", region))
+		}
+		sb.WriteString(fmt.Sprintf("Progress: %d%%\n", getNestedInt64(res.Object, "status", "progress")))
+	}
+
+	if errMessage := getNestedString(res.Object, "status", "error", "message"); errMessage != "" {
+		sb.WriteString(fmt.Sprintf("Error: %s\n", errMessage))
+	}
+
+	// Conditions
+	conditions, _, _ := unstructured.NestedSlice(res.Object, "status", "conditions")
+	if len(conditions) > 0 {
+		sb.WriteString("\nConditions:\n")
+		for _, condObj := range conditions {
+			cond, ok := condObj.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", getNestedString(cond, "type"), getNestedString(cond, "status")))
+			if reason := getNestedString(cond, "reason"); reason != "" {
+				sb.WriteString(fmt.Sprintf("    Reason: %s\n", reason))
+			}
+			if message := getNestedString(cond, "message"); message != "" {
+				sb.WriteString(fmt.Sprintf("    Message: %s\n", message))
+			}
+		}
+	}
+
+	// Volumes
+	volumeBackups, _, _ := unstructured.NestedSlice(res.Object, "status", "volumeBackups")
+	if len(volumeBackups) > 0 {
+		sb.WriteString("\nVolumes:\n")
+		for _, volumeObj := range volumeBackups {
+			volume, ok := volumeObj.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			sb.WriteString(fmt.Sprintf("  %s:\n", getNestedString(volume, "volumeName")))
+			if claimName := getNestedString(volume, "persistentVolumeClaim", "metadata", "name"); claimName != "" {
+				sb.WriteString(fmt.Sprintf("    PVC: %s\n", claimName))
+			}
+			if size := vmBackupVolumeSize(volume); size != "" {
+				sb.WriteString(fmt.Sprintf("    Size: %s\n", size))
+			}
+			sb.WriteString(fmt.Sprintf("    Ready: %t\n", getNestedBool(volume, "readyToUse")))
+			if errMessage := getNestedString(volume, "error", "message"); errMessage != "" {
+				sb.WriteString(fmt.Sprintf("    Error: %s\n", errMessage))
+			}
+		}
+	}
+
+	// Creation time
+	creationTime := res.GetCreationTimestamp().Format(time.RFC3339)
+	sb.WriteString(fmt.Sprintf("\nCreated: %s\n", creationTime))
+
+	return sb.String()
+}
+
+func (f *VMBackupFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
+	if len(list.Items) == 0 {
+		return "No VM backups or snapshots found in the specified namespace(s)."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d VM %s(s):\n\n", len(list.Items), vmBackupType(&list.Items[0])))
+
+	// Group backups by namespace
+	backupsByNamespace := make(map[string][]unstructured.Unstructured)
+	for _, item := range list.Items {
+		namespace := item.GetNamespace()
+		backupsByNamespace[namespace] = append(backupsByNamespace[namespace], item)
+	}
+
+	// Print backups grouped by namespace
+	for namespace, backups := range backupsByNamespace {
+		sb.WriteString(fmt.Sprintf("Namespace: %s (%d items)\n", namespace, len(backups)))
+
+		for _, backup := range backups {
+			volumeBackups, _, _ := unstructured.NestedSlice(backup.Object, "status", "volumeBackups")
+
+			// Basic backup info
+			sb.WriteString(fmt.Sprintf("  • %s\n", backup.GetName()))
+			sb.WriteString(fmt.Sprintf("    Source VM: %s\n", getNestedString(backup.Object, "spec", "source", "name")))
+			sb.WriteString(fmt.Sprintf("    Ready To Use: %t\n", getNestedBool(backup.Object, "status", "readyToUse")))
+			if vmBackupType(&backup) == VMBackupTypeBackup {
+				if endpoint := getNestedString(backup.Object, "status", "backupTarget", "endpoint"); endpoint != "" {
+					sb.WriteString(fmt.Sprintf("    Backup Target: %s\n", endpoint))
+				}
+				sb.WriteString(fmt.Sprintf("    Progress: %d%%\n", getNestedInt64(backup.Object, "status", "progress")))
+			}
+			sb.WriteString(fmt.Sprintf("    Volumes: %d\n", len(volumeBackups)))
+			if errMessage := getNestedString(backup.Object, "status", "error", "message"); errMessage != "" {
+				sb.WriteString(fmt.Sprintf("    Error: %s\n", errMessage))
+			}
+
+			// Creation time
+			creationTime := backup.GetCreationTimestamp().Format(time.RFC3339)
+			sb.WriteString(fmt.Sprintf("    Created: %s\n", creationTime))
+
+			sb.WriteString("\n")
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// vmBackupVolumeSize returns the size of a backed up volume in human-readable form.
+func vmBackupVolumeSize(volume map[string]interface{}) string {
+	if size := getNestedInt64(volume, "volumeSize"); size > 0 {
+		return resource.NewQuantity(size, resource.BinarySI).String()
+	}
+	return getNestedString(volume, "persistentVolumeClaim", "spec", "resources", "requests", "storage")
+}
+
+// VMRestoreFormatter handles formatting for VirtualMachineRestore resources
+type VMRestoreFormatter struct{}
+
+func (f *VMRestoreFormatter) FormatResource(res *unstructured.Unstructured) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("VM Restore: %s\n", res.GetName()))
+	sb.WriteString(fmt.Sprintf("Namespace: %s\n", res.GetNamespace()))
+
+	backupNamespace := getNestedString(res.Object, "spec", "virtualMachineBackupNamespace")
+	backupName := getNestedString(res.Object, "spec", "virtualMachineBackupName")
+	sb.WriteString(fmt.Sprintf("Source: %s/%s\n", backupNamespace, backupName))
+
+	targetName := getNestedString(res.Object, "spec", "target", "name")
+	if getNestedBool(res.Object, "spec", "newVM") {
+		sb.WriteString(fmt.Sprintf("Target VM: %s (new)\n", targetName))
+	} else {
+		sb.WriteString(fmt.Sprintf("Target VM: %s (in place)\n", targetName))
+	}
+
+	if deletionPolicy := getNestedString(res.Object, "spec", "deletionPolicy"); deletionPolicy != "" {
+		sb.WriteString(fmt.Sprintf("Deletion Policy: %s\n", deletionPolicy))
+	}
+	sb.WriteString(fmt.Sprintf("Complete: %t\n", getNestedBool(res.Object, "status", "complete")))
+
+	// Conditions
+	conditions, _, _ := unstructured.NestedSlice(res.Object, "status", "conditions")
+	if len(conditions) > 0 {
+		sb.WriteString("\nConditions:\n")
+		for _, condObj := range conditions {
+			cond, ok := condObj.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", getNestedString(cond, "type"), getNestedString(cond, "status")))
+			if message := getNestedString(cond, "message"); message != "" {
+				sb.WriteString(fmt.Sprintf("    Message: %s\n", message))
+			}
+		}
+	}
+
+	// Restored volumes
+	restores, _, _ := unstructured.NestedSlice(res.Object, "status", "restores")
+	if len(restores) > 0 {
+		sb.WriteString("\nRestored Volumes:\n")
+		for _, restoreObj := range restores {
+			restore, ok := restoreObj.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			claimName := getNestedString(restore, "persistentVolumeClaim", "metadata", "name")
+			sb.WriteString(fmt.Sprintf("  %s: PVC %s\n", getNestedString(restore, "volumeName"), claimName))
+		}
+	}
+
+	// Creation time
+	creationTime := res.GetCreationTimestamp().Format(time.RFC3339)
+	sb.WriteString(fmt.Sprintf("\nCreated: %s\n", creationTime))
+
+	return sb.String()
+}
+
+func (f *VMRestoreFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
+	if len(list.Items) == 0 {
+		return "No VM restores found in the specified namespace(s)."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d VM restore(s):\n\n", len(list.Items)))
+
+	// Group restores by namespace
+	restoresByNamespace := make(map[string][]unstructured.Unstructured)
+	for _, item := range list.Items {
+		namespace := item.GetNamespace()
+		restoresByNamespace[namespace] = append(restoresByNamespace[namespace], item)
+	}
+
+	// Print restores grouped by namespace
+	for namespace, restores := range restoresByNamespace {
+		sb.WriteString(fmt.Sprintf("Namespace: %s (%d restores)\n", namespace, len(restores)))
+
+		for _, restore := range restores {
+			sb.WriteString(fmt.Sprintf("  • %s\n", restore.GetName()))
+			sb.WriteString(fmt.Sprintf("    Source: %s\n", getNestedString(restore.Object, "spec", "virtualMachineBackupName")))
+			sb.WriteString(fmt.Sprintf("    Target VM: %s\n", getNestedString(restore.Object, "spec", "target", "name")))
+			sb.WriteString(fmt.Sprintf("    Complete: %t\n", getNestedBool(restore.Object, "status", "complete")))
+
+			// Creation time
+			creationTime := restore.GetCreationTimestamp().Format(time.RFC3339)
+			sb.WriteString(fmt.Sprintf("    Created: %s\n", creationTime))
+
+			sb.WriteString("\n")
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// SettingFormatter handles formatting for Harvester Setting resources
+type SettingFormatter struct{}
+
+func (f *SettingFormatter) FormatResource(res *unstructured.Unstructured) string {
+	if res.GetName() == SettingBackupTarget {
+		return formatBackupTargetSetting(res)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Setting: %s\n", res.GetName()))
+
+	value := getNestedString(res.Object, "value")
+	defaultValue := getNestedString(res.Object, "default")
+	if value != "" {
+		sb.WriteString(fmt.Sprintf("Value: %s\n", value))
+	} else {
+		sb.WriteString(fmt.Sprintf("Value: %s (default)\n", defaultValue))
+	}
+
+	if health, message := settingHealth(res); health != "" {
+		sb.WriteString(fmt.Sprintf("Status: %s\n", health))
+		if message != "" {
+			sb.WriteString(fmt.Sprintf("Message: %s\n", message))
+		}
+	}
+
+	return sb.String()
+}
+
+func (f *SettingFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
+	if len(list.Items) == 0 {
+		return "No settings found."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d setting(s):\n\n", len(list.Items)))
+
+	for _, setting := range list.Items {
+		value := getNestedString(setting.Object, "value")
+		if value == "" {
+			value = getNestedString(setting.Object, "default") + " (default)"
+		}
+		sb.WriteString(fmt.Sprintf("  • %s: %s\n", setting.GetName(), value))
+	}
+
+	return sb.String()
+}
+
+// formatBackupTargetSetting describes the backup target without exposing its credentials.
+func formatBackupTargetSetting(res *unstructured.Unstructured) string {
+	var sb strings.Builder
+	sb.WriteString("Backup Target\n")
+
+	value := getNestedString(res.Object, "value")
+	if value == "" {
+		sb.WriteString("Status: Not configured\n")
+		return sb.String()
+	}
+
+	target := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(value), &target); err != nil {
+		sb.WriteString(fmt.Sprintf("Status: Invalid configuration (%v)\n", err))
+		return sb.String()
+	}
+
+	if targetType := getNestedString(target, "type"); targetType != "" {
+		sb.WriteString(fmt.Sprintf("Type: %s\n", targetType))
+	}
+	if endpoint := getNestedString(target, "endpoint"); endpoint != "" {
+		sb.WriteString(fmt.Sprintf("Endpoint: %s\n", endpoint))
+	}
+	if bucket := getNestedString(target, "bucketName"); bucket != "" {
+		sb.WriteString(fmt.Sprintf("Bucket: %s\n", bucket))
+	}
+	if region := getNestedString(target, "bucketRegion"); region != "" {
+		sb.WriteString(fmt.Sprintf("Region: %s\n", region))
+	}
+
+	health, message := settingHealth(res)
+	if health == "" {
+		health = "Unknown"
+	}
+	sb.WriteString(fmt.Sprintf("Status: %s\n", health))
+	if message != "" {
+		sb.WriteString(fmt.Sprintf("Message: %s\n", message))
+	}
+
+	return sb.String()
+}
+
+// settingHealth derives a health summary from the "configured" condition Harvester sets on settings.
+func settingHealth(res *unstructured.Unstructured) (string, string) {
+	conditions, _, _ := unstructured.NestedSlice(res.Object, "status", "conditions")
+	for _, condObj := range conditions {
+		cond, ok := condObj.(map[string]interface{})
+		if !ok || !strings.EqualFold(getNestedString(cond, "type"), "configured") {
+			continue
+		}
+
+		message := getNestedString(cond, "message")
+		if getNestedString(cond, "status") == "True" {
+			return "Healthy", message
+		}
+		if reason := getNestedString(cond, "reason"); reason != "" && message == "" {
+			message = reason
+		}
+		return "Unhealthy", message
+	}
+	return "", ""
+}
+
+// FormatVMGuestInfo formats guest agent information of a virtual machine in a human-readable form
+func FormatVMGuestInfo(info *VMGuestInfo) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Guest Info: %s\n", info.VMI.GetName()))
+	sb.WriteString(fmt.Sprintf("Namespace: %s\n", info.VMI.GetNamespace()))
+
+	// Operating system
+	osName := getNestedString(info.OSInfo, "os", "prettyName")
+	if osName == "" {
+		osName = getNestedString(info.OSInfo, "os", "name")
+	}
+	sb.WriteString("\nOperating System:\n")
+	sb.WriteString(fmt.Sprintf("  Name: %s\n", osName))
+	if version := getNestedString(info.OSInfo, "os", "version"); version != "" {
+		sb.WriteString(fmt.Sprintf("  Version: %s\n", version))
+	}
+	if kernel := getNestedString(info.OSInfo, "os", "kernelRelease"); kernel != "" {
+		sb.WriteString(fmt.Sprintf("  Kernel: %s\n", kernel))
+	}
+	if machine := getNestedString(info.OSInfo, "os", "machine"); machine != "" {
+		sb.WriteString(fmt.Sprintf("  Architecture: %s\n", machine))
+	}
+	if hostname := getNestedString(info.OSInfo, "hostname"); hostname != "" {
+		sb.WriteString(fmt.Sprintf("  Hostname: %s\n", hostname))
+	}
+	if timezone := getNestedString(info.OSInfo, "timezone"); timezone != "" {
+		sb.WriteString(fmt.Sprintf("  Timezone: %s\n", timezone))
+	}
+	if agentVersion := getNestedString(info.OSInfo, "guestAgentVersion"); agentVersion != "" {
+		sb.WriteString(fmt.Sprintf("  Guest Agent Version: %s\n", agentVersion))
+	}
+
+	// Logged-in users
+	if info.Users != nil {
+		sb.WriteString(fmt.Sprintf("\nLogged-in Users (%d):\n", len(info.Users)))
+		for _, userObj := range info.Users {
+			user, ok := userObj.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			userName := getNestedString(user, "userName")
+			if domain := getNestedString(user, "domain"); domain != "" {
+				userName = domain + "\\" + userName
+			}
+			if loginTime, ok := user["loginTime"].(float64); ok && loginTime > 0 {
+				login := time.Unix(int64(loginTime), 0).UTC().Format(time.RFC3339)
+				sb.WriteString(fmt.Sprintf("  %s (since %s)\n", userName, login))
+			} else {
+				sb.WriteString(fmt.Sprintf("  %s\n", userName))
+			}
+		}
+	}
 
-			sb.WriteString(fmt.Sprintf("  %s:\n", name))
-			sb.WriteString(fmt.Sprintf("    Served: %t\n", served))
-			sb.WriteString(fmt.Sprintf("    Storage: %t\n", storage))
+	// Filesystems
+	if info.Filesystems != nil {
+		sb.WriteString("\nFilesystems:\n")
+		for _, fsObj := range info.Filesystems {
+			fs, ok := fsObj.(map[string]interface{})
+			if !ok {
+				continue
+			}
 
-			// Schema details if present
-			schema, schemaFound, _ := unstructured.NestedMap(version, "schema", "openAPIV3Schema")
-			if schemaFound && len(schema) > 0 {
-				sb.WriteString("    Schema: Available\n")
+			mountPoint := getNestedString(fs, "mountPoint")
+			fsType := getNestedString(fs, "fileSystemType")
+			sb.WriteString(fmt.Sprintf("  %s (%s on %s)\n", mountPoint, fsType, getNestedString(fs, "diskName")))
+
+			used, _ := fs["usedBytes"].(float64)
+			total, _ := fs["totalBytes"].(float64)
+			if total > 0 {
+				usedQuantity := resource.NewQuantity(int64(used), resource.BinarySI)
+				totalQuantity := resource.NewQuantity(int64(total), resource.BinarySI)
+				sb.WriteString(fmt.Sprintf("    Usage: %s / %s (%.1f%%)\n", usedQuantity.String(), totalQuantity.String(), used/total*100))
+			}
+		}
+	}
+
+	// Network interfaces
+	interfaces, _, _ := unstructured.NestedSlice(info.VMI.Object, "status", "interfaces")
+	if len(interfaces) > 0 {
+		sb.WriteString("\nNetwork Interfaces:\n")
+		for _, ifaceObj := range interfaces {
+			iface, ok := ifaceObj.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			name := getNestedString(iface, "name")
+			if name == "" {
+				name = getNestedString(iface, "interfaceName")
+			}
+			sb.WriteString(fmt.Sprintf("  %s:\n", name))
+			if mac := getNestedString(iface, "mac"); mac != "" {
+				sb.WriteString(fmt.Sprintf("    MAC: %s\n", mac))
+			}
+			ips := getNestedStringSlice(iface, "ipAddresses")
+			if len(ips) == 0 {
+				if ip := getNestedString(iface, "ipAddress"); ip != "" {
+					ips = []string{ip}
+				}
 			}
+			if len(ips) > 0 {
+				sb.WriteString(fmt.Sprintf("    IPs: %s\n", strings.Join(ips, ", ")))
+			}
+		}
+	}
+
+	if len(info.Errors) > 0 {
+		sb.WriteString("\nUnavailable:\n")
+		for _, errMessage := range info.Errors {
+			sb.WriteString(fmt.Sprintf("  %s\n", errMessage))
+		}
+	}
+
+	return sb.String()
+}
+
+// FormatVolumeDiagnosis formats the Longhorn health of a volume in a human-readable form
+func FormatVolumeDiagnosis(diagnosis *VolumeDiagnosis) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Volume Diagnosis: %s\n", diagnosis.PVC.GetName()))
+	sb.WriteString(fmt.Sprintf("Namespace: %s\n", diagnosis.PVC.GetNamespace()))
+	sb.WriteString(fmt.Sprintf("Status: %s\n", getNestedString(diagnosis.PVC.Object, "status", "phase")))
+	sb.WriteString(fmt.Sprintf("Size: %s\n", volumeSize(diagnosis.PVC)))
+
+	sb.WriteString("\nSummary:\n")
+	for _, line := range diagnosis.Summary {
+		sb.WriteString(fmt.Sprintf("  • %s\n", line))
+	}
+
+	if diagnosis.Volume == nil {
+		return sb.String()
+	}
+
+	// Longhorn volume
+	volume := diagnosis.Volume.Object
+	sb.WriteString("\nLonghorn Volume:\n")
+	sb.WriteString(fmt.Sprintf("  Name: %s\n", diagnosis.Volume.GetName()))
+	sb.WriteString(fmt.Sprintf("  State: %s\n", getNestedString(volume, "status", "state")))
+	sb.WriteString(fmt.Sprintf("  Robustness: %s\n", getNestedString(volume, "status", "robustness")))
+	sb.WriteString(fmt.Sprintf("  Desired Replicas: %d\n", getNestedInt64(volume, "spec", "numberOfReplicas")))
+	if node := getNestedString(volume, "status", "currentNodeID"); node != "" {
+		sb.WriteString(fmt.Sprintf("  Attached Node: %s\n", node))
+	}
+	if dataLocality := getNestedString(volume, "spec", "dataLocality"); dataLocality != "" {
+		sb.WriteString(fmt.Sprintf("  Data Locality: %s\n", dataLocality))
+	}
+	if diagnosis.SchedulingFailure != "" {
+		sb.WriteString(fmt.Sprintf("  Scheduling Failure: %s\n", diagnosis.SchedulingFailure))
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(volume, "status", "conditions")
+	for _, condObj := range conditions {
+		cond, ok := condObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if message := getNestedString(cond, "message"); message != "" {
+			sb.WriteString(fmt.Sprintf("  Condition %s=%s: %s\n", getNestedString(cond, "type"), getNestedString(cond, "status"), message))
+		}
+	}
+
+	// Engines
+	if len(diagnosis.Engines) > 0 {
+		sb.WriteString(fmt.Sprintf("\nEngines (%d):\n", len(diagnosis.Engines)))
+		for _, engine := range diagnosis.Engines {
+			sb.WriteString(fmt.Sprintf("  • %s\n", engine.Name))
+			sb.WriteString(fmt.Sprintf("    Node: %s\n", engine.Node))
+			sb.WriteString(fmt.Sprintf("    State: %s\n", engine.State))
+		}
+	}
+
+	// Replicas
+	sb.WriteString(fmt.Sprintf("\nReplicas (%d):\n", len(diagnosis.Replicas)))
+	for _, replica := range diagnosis.Replicas {
+		sb.WriteString(fmt.Sprintf("  • %s\n", replica.Name))
+		if replica.Node != "" {
+			sb.WriteString(fmt.Sprintf("    Node: %s\n", replica.Node))
+		} else {
+			sb.WriteString("    Node: not scheduled\n")
+		}
+		if replica.DiskPath != "" {
+			sb.WriteString(fmt.Sprintf("    Disk: %s\n", replica.DiskPath))
+		}
+		if replica.State != "" {
+			sb.WriteString(fmt.Sprintf("    State: %s\n", replica.State))
+		}
+		if replica.Mode != "" {
+			sb.WriteString(fmt.Sprintf("    Mode: %s\n", replica.Mode))
+		}
+		sb.WriteString(fmt.Sprintf("    Healthy: %t\n", replica.Healthy))
+		if replica.Rebuilding {
+			sb.WriteString(fmt.Sprintf("    Rebuilding: %d%%\n", replica.Progress))
+		}
+		if replica.RebuildError != "" {
+			sb.WriteString(fmt.Sprintf("    Rebuild Error: %s\n", replica.RebuildError))
+		}
+		if replica.FailedAt != "" {
+			sb.WriteString(fmt.Sprintf("    Failed At: %s\n", replica.FailedAt))
 		}
 	}
 
+	return sb.String()
+}
+
+// VMTemplateFormatter handles formatting for VirtualMachineTemplate resources
+type VMTemplateFormatter struct{}
+
+func (f *VMTemplateFormatter) FormatResource(res *unstructured.Unstructured) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("VM Template: %s\n", res.GetName()))
+	sb.WriteString(fmt.Sprintf("Namespace: %s\n", res.GetNamespace()))
+
+	if description := getNestedString(res.Object, "spec", "description"); description != "" {
+		sb.WriteString(fmt.Sprintf("Description: %s\n", description))
+	}
+	sb.WriteString(fmt.Sprintf("Default Version: %s\n", vmTemplateDefaultVersion(res)))
+	sb.WriteString(fmt.Sprintf("Latest Version: %d\n", getNestedInt64(res.Object, "status", "latestVersion")))
+
 	// Creation time
 	creationTime := res.GetCreationTimestamp().Format(time.RFC3339)
 	sb.WriteString(fmt.Sprintf("\nCreated: %s\n", creationTime))
@@ -563,65 +2574,172 @@ func (f *CRDFormatter) FormatResource(res *unstructured.Unstructured) string {
 	return sb.String()
 }
 
-func (f *CRDFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
+func (f *VMTemplateFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
 	if len(list.Items) == 0 {
-		return "No custom resource definitions found."
+		return "No VM templates found in the specified namespace(s)."
 	}
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Found %d custom resource definition(s):\n\n", len(list.Items)))
+	sb.WriteString(fmt.Sprintf("Found %d VM template(s):\n\n", len(list.Items)))
 
-	// Group CRDs by group
-	crdsByGroup := make(map[string][]unstructured.Unstructured)
+	// Group templates by namespace
+	templatesByNamespace := make(map[string][]unstructured.Unstructured)
 	for _, item := range list.Items {
-		group := getNestedString(item.Object, "spec", "group")
-		if group == "" {
-			group = "core"
+		namespace := item.GetNamespace()
+		templatesByNamespace[namespace] = append(templatesByNamespace[namespace], item)
+	}
+
+	// Print templates grouped by namespace
+	for namespace, templates := range templatesByNamespace {
+		sb.WriteString(fmt.Sprintf("Namespace: %s (%d templates)\n", namespace, len(templates)))
+
+		for _, template := range templates {
+			sb.WriteString(fmt.Sprintf("  • %s\n", template.GetName()))
+			if description := getNestedString(template.Object, "spec", "description"); description != "" {
+				sb.WriteString(fmt.Sprintf("    Description: %s\n", description))
+			}
+			sb.WriteString(fmt.Sprintf("    Default Version: %s\n", vmTemplateDefaultVersion(&template)))
+			sb.WriteString(fmt.Sprintf("    Latest Version: %d\n", getNestedInt64(template.Object, "status", "latestVersion")))
+
+			sb.WriteString("\n")
 		}
-		crdsByGroup[group] = append(crdsByGroup[group], item)
+
+		sb.WriteString("\n")
 	}
 
-	// Print CRDs grouped by group
-	for group, crds := range crdsByGroup {
-		sb.WriteString(fmt.Sprintf("Group: %s (%d CRDs)\n", group, len(crds)))
+	return sb.String()
+}
 
-		for _, crd := range crds {
-			// Get basic info
-			kind := getNestedString(crd.Object, "spec", "names", "kind")
-			plural := getNestedString(crd.Object, "spec", "names", "plural")
+// vmTemplateDefaultVersion describes the default version of a template by number and version resource.
+func vmTemplateDefaultVersion(res *unstructured.Unstructured) string {
+	defaultVersionID := getNestedString(res.Object, "spec", "defaultVersionId")
+	if defaultVersionID == "" {
+		return "None"
+	}
+	if version := getNestedInt64(res.Object, "status", "defaultVersion"); version > 0 {
+		return fmt.Sprintf("%d (%s)", version, defaultVersionID)
+	}
+	return defaultVersionID
+}
 
-			// Basic CRD info
-			sb.WriteString(fmt.Sprintf("  • %s\n", crd.GetName()))
-			sb.WriteString(fmt.Sprintf("    Kind: %s\n", kind))
-			sb.WriteString(fmt.Sprintf("    Plural: %s\n", plural))
+// VMTemplateVersionFormatter handles formatting for VirtualMachineTemplateVersion resources
+type VMTemplateVersionFormatter struct{}
 
-			// Versions
-			versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
-			if len(versions) > 0 {
-				sb.WriteString("    Versions:\n")
-				for _, versionObj := range versions {
-					version, ok := versionObj.(map[string]interface{})
-					if !ok {
-						continue
-					}
+func (f *VMTemplateVersionFormatter) FormatResource(res *unstructured.Unstructured) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("VM Template Version: %s\n", res.GetName()))
+	sb.WriteString(fmt.Sprintf("Namespace: %s\n", res.GetNamespace()))
+	sb.WriteString(fmt.Sprintf("Template: %s\n", getNestedString(res.Object, "spec", "templateId")))
+	sb.WriteString(fmt.Sprintf("Version: %d\n", getNestedInt64(res.Object, "status", "version")))
 
-					name, _, _ := unstructured.NestedString(version, "name")
-					served, _, _ := unstructured.NestedBool(version, "served")
-					storage, _, _ := unstructured.NestedBool(version, "storage")
+	if description := getNestedString(res.Object, "spec", "description"); description != "" {
+		sb.WriteString(fmt.Sprintf("Description: %s\n", description))
+	}
+	if imageID := getNestedString(res.Object, "spec", "imageId"); imageID != "" {
+		sb.WriteString(fmt.Sprintf("Image: %s\n", imageID))
+	}
+	if keyPairs := getNestedStringSlice(res.Object, "spec", "keyPairIds"); len(keyPairs) > 0 {
+		sb.WriteString(fmt.Sprintf("SSH Keys: %s\n", strings.Join(keyPairs, ", ")))
+	}
 
-					sb.WriteString(fmt.Sprintf("      %s (served: %t, storage: %t)\n", name, served, storage))
-				}
+	// Conditions
+	conditions, _, _ := unstructured.NestedSlice(res.Object, "status", "conditions")
+	if len(conditions) > 0 {
+		sb.WriteString("\nConditions:\n")
+		for _, condObj := range conditions {
+			cond, ok := condObj.(map[string]interface{})
+			if !ok {
+				continue
 			}
 
-			// Creation time
-			creationTime := crd.GetCreationTimestamp().Format(time.RFC3339)
-			sb.WriteString(fmt.Sprintf("    Created: %s\n", creationTime))
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", getNestedString(cond, "type"), getNestedString(cond, "status")))
+			if message := getNestedString(cond, "message"); message != "" {
+				sb.WriteString(fmt.Sprintf("    Message: %s\n", message))
+			}
+		}
+	}
 
-			sb.WriteString("\n")
+	// Full VM spec stored in the version
+	if vm := getNestedMap(res.Object, "spec", "vm"); len(vm) > 0 {
+		data, err := yaml.Marshal(vm)
+		if err != nil {
+			sb.WriteString(fmt.Sprintf("\nVM Spec: failed to encode: %v\n", err))
+		} else {
+			sb.WriteString("\nVM Spec:\n")
+			sb.WriteString(string(data))
+		}
+	}
+
+	// Creation time
+	creationTime := res.GetCreationTimestamp().Format(time.RFC3339)
+	sb.WriteString(fmt.Sprintf("\nCreated: %s\n", creationTime))
+
+	return sb.String()
+}
+
+func (f *VMTemplateVersionFormatter) FormatResourceList(list *unstructured.UnstructuredList) string {
+	if len(list.Items) == 0 {
+		return "No VM template versions found."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d VM template version(s):\n\n", len(list.Items)))
+
+	for _, version := range list.Items {
+		sb.WriteString(fmt.Sprintf("  • %s\n", version.GetName()))
+		sb.WriteString(fmt.Sprintf("    Template: %s\n", getNestedString(version.Object, "spec", "templateId")))
+		sb.WriteString(fmt.Sprintf("    Version: %d\n", getNestedInt64(version.Object, "status", "version")))
+		if description := getNestedString(version.Object, "spec", "description"); description != "" {
+			sb.WriteString(fmt.Sprintf("    Description: %s\n", description))
 		}
 
+		// Creation time
+		creationTime := version.GetCreationTimestamp().Format(time.RFC3339)
+		sb.WriteString(fmt.Sprintf("    Created: %s\n", creationTime))
+
 		sb.WriteString("\n")
 	}
 
 	return sb.String()
 }
+
+// FormatNodeMaintenanceResult formats the progress of a node entering maintenance mode in a human-readable form
+func FormatNodeMaintenanceResult(result *NodeMaintenanceResult) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Node: %s\n", result.Node.GetName()))
+	if status := result.Node.GetAnnotations()[annotationMaintainStatus]; status != "" {
+		sb.WriteString(fmt.Sprintf("Maintenance Mode: %s\n", status))
+	} else {
+		sb.WriteString("Maintenance Mode: disabled\n")
+	}
+	sb.WriteString(fmt.Sprintf("Schedulable: %t\n", !getNestedBool(result.Node.Object, "spec", "unschedulable")))
+
+	if len(result.Migrated) > 0 {
+		sb.WriteString(fmt.Sprintf("\nMigrated VMs (%d):\n", len(result.Migrated)))
+		for _, vm := range result.Migrated {
+			sb.WriteString(fmt.Sprintf("  • %s\n", vm))
+		}
+	}
+	if len(result.Stopped) > 0 {
+		sb.WriteString(fmt.Sprintf("\nStopped VMs (%d):\n", len(result.Stopped)))
+		for _, vm := range result.Stopped {
+			sb.WriteString(fmt.Sprintf("  • %s\n", vm))
+		}
+	}
+	if len(result.Blocking) > 0 {
+		var vms []string
+		for vm := range result.Blocking {
+			vms = append(vms, vm)
+		}
+		sort.Strings(vms)
+		sb.WriteString(fmt.Sprintf("\nBlocking VMs (%d):\n", len(vms)))
+		for _, vm := range vms {
+			sb.WriteString(fmt.Sprintf("  • %s: %s\n", vm, result.Blocking[vm]))
+		}
+	}
+	if len(result.Migrated) == 0 && len(result.Stopped) == 0 && len(result.Blocking) == 0 {
+		sb.WriteString("\nNo VMs were running on the node.\n")
+	}
+
+	return sb.String()
+}
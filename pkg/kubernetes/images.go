@@ -0,0 +1,329 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+)
+
+// VirtualMachineImage source types
+const (
+	ImageSourceTypeDownload     = "download"
+	ImageSourceTypeUpload       = "upload"
+	ImageSourceTypeExportVolume = "export-from-volume"
+)
+
+// annotationImageStorageClass selects the storage class whose parameters the Harvester webhook copies into an image.
+const annotationImageStorageClass = "harvesterhci.io/storageClassName"
+
+// rancherClusterPathSuffix is the path under which Rancher proxies the Kubernetes API of the local cluster.
+const rancherClusterPathSuffix = "/k8s/clusters/local"
+
+// imagePollInterval is how often an image is checked while waiting for it to be imported.
+const imagePollInterval = 5 * time.Second
+
+// ImageProgress is the import progress of an image observed after some time.
+type ImageProgress struct {
+	Elapsed time.Duration
+	Percent int64
+}
+
+// ImageWaitResult describes an image after waiting for it to be imported.
+type ImageWaitResult struct {
+	Image *unstructured.Unstructured
+	// Completed is true when the image was imported before the wait timed out.
+	Completed bool
+	// Progress lists every change of the import progress observed while waiting.
+	Progress []ImageProgress
+}
+
+// ImageCreateOptions describes a VirtualMachineImage to be created. A name is generated when empty.
+type ImageCreateOptions struct {
+	Name         string
+	Namespace    string
+	DisplayName  string
+	Description  string
+	StorageClass string
+	// URL is the download location of the image, used by CreateImageFromURL.
+	URL      string
+	Checksum string
+}
+
+// CreateImageFromURL creates a VirtualMachineImage that Harvester downloads from a URL.
+func (h *ResourceHandler) CreateImageFromURL(ctx context.Context, opts ImageCreateOptions) (*unstructured.Unstructured, error) {
+	if _, err := url.ParseRequestURI(opts.URL); err != nil {
+		return nil, fmt.Errorf("invalid image URL %q: %w", opts.URL, err)
+	}
+	if opts.DisplayName == "" {
+		opts.DisplayName = filepath.Base(opts.URL)
+	}
+
+	image := buildImage(opts, ImageSourceTypeDownload)
+	if err := unstructured.SetNestedField(image.Object, opts.URL, "spec", "url"); err != nil {
+		return nil, err
+	}
+
+	return h.CreateResource(ctx, ResourceTypeToGVR[ResourceTypeImage], opts.Namespace, image)
+}
+
+// UploadImage creates a VirtualMachineImage with the upload source type and streams a local file into it
+// through the Harvester upload endpoint. The image is deleted again when the upload fails.
+func (h *ResourceHandler) UploadImage(ctx context.Context, opts ImageCreateOptions, filePath string) (*unstructured.Unstructured, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image file: %w", err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("image file %s is a directory", filePath)
+	}
+	if opts.DisplayName == "" {
+		opts.DisplayName = filepath.Base(filePath)
+	}
+
+	uploader, err := NewImageUploader(h.client.Config, h.client.HarvesterAPIURL)
+	if err != nil {
+		return nil, err
+	}
+
+	gvr := ResourceTypeToGVR[ResourceTypeImage]
+	image, err := h.CreateResource(ctx, gvr, opts.Namespace, buildImage(opts, ImageSourceTypeUpload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create image: %w", err)
+	}
+
+	if err := uploader.Upload(ctx, image.GetNamespace(), image.GetName(), filePath); err != nil {
+		if deleteErr := h.DeleteResource(ctx, gvr, image.GetNamespace(), image.GetName()); deleteErr != nil {
+			return nil, fmt.Errorf("%w (failed to delete image %s/%s: %v)", err, image.GetNamespace(), image.GetName(), deleteErr)
+		}
+		return nil, err
+	}
+
+	return h.GetResource(ctx, gvr, image.GetNamespace(), image.GetName())
+}
+
+// ExportVolumeToImage creates a VirtualMachineImage from the contents of a volume claim and waits up to
+// timeout for the export to complete.
+func (h *ResourceHandler) ExportVolumeToImage(ctx context.Context, opts ImageCreateOptions, pvcNamespace, pvcName string, timeout time.Duration) (*ImageWaitResult, error) {
+	if pvcNamespace == "" {
+		pvcNamespace = opts.Namespace
+	}
+	pvc, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypePVC], pvcNamespace, pvcName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volume %s/%s: %w", pvcNamespace, pvcName, err)
+	}
+	if opts.DisplayName == "" {
+		opts.DisplayName = pvcName
+	}
+	if opts.StorageClass == "" {
+		opts.StorageClass = getNestedString(pvc.Object, "spec", "storageClassName")
+	}
+
+	image := buildImage(opts, ImageSourceTypeExportVolume)
+	if err := unstructured.SetNestedField(image.Object, pvcName, "spec", "pvcName"); err != nil {
+		return nil, err
+	}
+	if err := unstructured.SetNestedField(image.Object, pvcNamespace, "spec", "pvcNamespace"); err != nil {
+		return nil, err
+	}
+
+	created, err := h.CreateResource(ctx, ResourceTypeToGVR[ResourceTypeImage], opts.Namespace, image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create image: %w", err)
+	}
+
+	return h.WaitForImage(ctx, created.GetNamespace(), created.GetName(), timeout)
+}
+
+// WaitForImage waits up to timeout for a VirtualMachineImage to be imported, recording its progress on the way.
+// Running out of time is not an error: the result then reports the image as incomplete.
+func (h *ResourceHandler) WaitForImage(ctx context.Context, namespace, name string, timeout time.Duration) (*ImageWaitResult, error) {
+	gvr := ResourceTypeToGVR[ResourceTypeImage]
+	result := &ImageWaitResult{}
+	start := time.Now()
+	lastProgress := int64(-1)
+
+	err := wait.PollUntilContextTimeout(ctx, imagePollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		image, err := h.GetResource(ctx, gvr, namespace, name)
+		if err != nil {
+			return false, err
+		}
+		result.Image = image
+
+		if progress := getNestedInt64(image.Object, "status", "progress"); progress != lastProgress {
+			lastProgress = progress
+			result.Progress = append(result.Progress, ImageProgress{Elapsed: time.Since(start).Round(time.Second), Percent: progress})
+		}
+
+		switch imageState(image) {
+		case "Active":
+			result.Completed = true
+			return true, nil
+		case "Failed":
+			if message := imageFailureMessage(image); message != "" {
+				return false, fmt.Errorf("image %s/%s failed to import: %s", namespace, name, message)
+			}
+			return false, fmt.Errorf("image %s/%s failed to import", namespace, name)
+		}
+		return false, nil
+	})
+	if err != nil && !wait.Interrupted(err) {
+		return nil, err
+	}
+	// The wait can also be interrupted by a cancelled context before the image was read even once
+	if result.Image == nil {
+		return nil, fmt.Errorf("failed to get image %s/%s: %w", namespace, name, err)
+	}
+
+	return result, nil
+}
+
+// GetImage retrieves a VirtualMachineImage by resource name or display name.
+func (h *ResourceHandler) GetImage(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error) {
+	return h.resolveImage(ctx, namespace, name)
+}
+
+// DeleteImage deletes a VirtualMachineImage unless a virtual machine disk was provisioned from it.
+func (h *ResourceHandler) DeleteImage(ctx context.Context, namespace, name string) error {
+	gvr := ResourceTypeToGVR[ResourceTypeImage]
+	if _, err := h.GetResource(ctx, gvr, namespace, name); err != nil {
+		return err
+	}
+
+	pvcs, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypePVCs], "")
+	if err != nil {
+		return fmt.Errorf("failed to list volume claims: %w", err)
+	}
+	var users []string
+	for _, pvc := range pvcs.Items {
+		if pvc.GetAnnotations()[annotationImageID] == namespace+"/"+name {
+			users = append(users, pvc.GetNamespace()+"/"+pvc.GetName())
+		}
+	}
+	if len(users) > 0 {
+		return fmt.Errorf("image %s/%s is in use by volumes %s", namespace, name, strings.Join(users, ", "))
+	}
+
+	return h.DeleteResource(ctx, gvr, namespace, name)
+}
+
+// buildImage assembles a VirtualMachineImage of the given source type the same way the Harvester UI does.
+func buildImage(opts ImageCreateOptions, sourceType string) *unstructured.Unstructured {
+	metadata := map[string]interface{}{
+		"namespace": opts.Namespace,
+	}
+	if opts.Name != "" {
+		metadata["name"] = opts.Name
+	} else {
+		metadata["generateName"] = "image-"
+	}
+	if opts.StorageClass != "" {
+		metadata["annotations"] = map[string]interface{}{
+			annotationImageStorageClass: opts.StorageClass,
+		}
+	}
+
+	spec := map[string]interface{}{
+		"displayName": opts.DisplayName,
+		"sourceType":  sourceType,
+	}
+	if opts.Description != "" {
+		spec["description"] = opts.Description
+	}
+	if opts.Checksum != "" {
+		spec["checksum"] = opts.Checksum
+	}
+
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "harvesterhci.io/v1beta1",
+		"kind":       "VirtualMachineImage",
+		"metadata":   metadata,
+		"spec":       spec,
+	}}
+}
+
+// ImageUploader streams image files to the upload action of the Harvester API.
+type ImageUploader struct {
+	// Endpoint is the base URL of the Harvester API, e.g. https://harvester.example.com.
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+// NewImageUploader creates an ImageUploader that authenticates like the Kubernetes client. When endpoint
+// is empty it is derived from the Kubernetes API server address, which works for Harvester kubeconfigs.
+func NewImageUploader(config *rest.Config, endpoint string) (*ImageUploader, error) {
+	endpoint, httpClient, err := newHarvesterAPIClient(config, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	// Uploads can take much longer than regular API requests
+	httpClient.Timeout = 0
+
+	return &ImageUploader{Endpoint: endpoint, HTTPClient: httpClient}, nil
+}
+
+// Upload streams a local file to the upload action of a VirtualMachineImage as a multipart form.
+func (u *ImageUploader) Upload(ctx context.Context, namespace, name, filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open image file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to read image file: %w", err)
+	}
+
+	body, writer := io.Pipe()
+	defer body.Close()
+	form := multipart.NewWriter(writer)
+	go func() {
+		part, err := form.CreateFormFile("chunk", filepath.Base(filePath))
+		if err == nil {
+			_, err = io.Copy(part, file)
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+
+	uploadURL := fmt.Sprintf("%s/v1/harvester/harvesterhci.io.virtualmachineimages/%s/%s?action=upload&size=%d",
+		strings.TrimSuffix(u.Endpoint, "/"), url.PathEscape(namespace), url.PathEscape(name), info.Size())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, body)
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	resp, err := u.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("failed to upload image: %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	return nil
+}
+
+// HarvesterAPIURLFromHost derives the Harvester API base URL from a Kubernetes API server address,
+// dropping the Rancher cluster proxy path used by kubeconfigs downloaded from Harvester.
+func HarvesterAPIURLFromHost(host string) string {
+	host = strings.TrimSuffix(host, "/")
+	return strings.TrimSuffix(host, rancherClusterPathSuffix)
+}
@@ -0,0 +1,144 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// imageUploadStandIn serves the VirtualMachineImage API and the Harvester image upload action.
+type imageUploadStandIn struct {
+	t            *testing.T
+	uploadStatus int
+
+	mu       sync.Mutex
+	image    map[string]interface{}
+	uploaded []byte
+	deleted  bool
+}
+
+func (s *imageUploadStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const imagesPath = "/apis/harvesterhci.io/v1beta1/namespaces/" + testNamespace + "/virtualmachineimages"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == imagesPath:
+		if err := json.NewDecoder(r.Body).Decode(&s.image); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		metadata := s.image["metadata"].(map[string]interface{})
+		metadata["name"] = metadata["generateName"].(string) + "abcde"
+		writeTestJSON(w, http.StatusCreated, s.image)
+	case r.Method == http.MethodGet && r.URL.Path == imagesPath+"/image-abcde" && s.image != nil:
+		writeTestJSON(w, http.StatusOK, s.image)
+	case r.Method == http.MethodDelete && r.URL.Path == imagesPath+"/image-abcde":
+		s.deleted = true
+		writeTestJSON(w, http.StatusOK, map[string]interface{}{"apiVersion": "v1", "kind": "Status", "status": "Success"})
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/harvester/harvesterhci.io.virtualmachineimages/"+testNamespace+"/image-abcde":
+		s.handleUpload(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *imageUploadStandIn) handleUpload(w http.ResponseWriter, r *http.Request) {
+	if got := r.URL.Query().Get("action"); got != "upload" {
+		s.t.Errorf("upload action = %q, want %q", got, "upload")
+	}
+	if got, want := r.Header.Get("Authorization"), "Bearer "+testBearerToken; got != want {
+		s.t.Errorf("upload Authorization header = %q, want %q", got, want)
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		s.t.Errorf("upload body is not a multipart form: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	part, err := reader.NextPart()
+	if err != nil {
+		s.t.Errorf("failed to read upload form: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if part.FormName() != "chunk" || part.FileName() != "disk.img" {
+		s.t.Errorf("upload form part = %q (file %q), want %q (file %q)", part.FormName(), part.FileName(), "chunk", "disk.img")
+	}
+	if s.uploaded, err = io.ReadAll(part); err != nil {
+		s.t.Errorf("failed to read upload chunk: %v", err)
+	}
+	if got := r.URL.Query().Get("size"); got != strconv.Itoa(len(s.uploaded)) {
+		s.t.Errorf("upload size = %s, want %d", got, len(s.uploaded))
+	}
+
+	if s.uploadStatus != http.StatusOK {
+		http.Error(w, "upload rejected", s.uploadStatus)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func writeTestJSON(w http.ResponseWriter, status int, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(obj)
+}
+
+func TestUploadImage(t *testing.T) {
+	content := []byte("QFI\xfb disk image contents")
+	filePath := filepath.Join(t.TempDir(), "disk.img")
+	if err := os.WriteFile(filePath, content, 0o600); err != nil {
+		t.Fatalf("failed to write image file: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		uploadStatus int
+		wantErr      bool
+		wantDeleted  bool
+	}{
+		{name: "upload succeeds", uploadStatus: http.StatusOK},
+		{name: "upload rejected", uploadStatus: http.StatusInternalServerError, wantErr: true, wantDeleted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standIn := &imageUploadStandIn{t: t, uploadStatus: tt.uploadStatus}
+			h := newTestResourceHandler(t, standIn)
+
+			image, err := h.UploadImage(context.Background(), ImageCreateOptions{Namespace: testNamespace}, filePath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UploadImage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				if image.GetName() != "image-abcde" {
+					t.Errorf("UploadImage() image = %s, want image-abcde", image.GetName())
+				}
+				if got := getNestedString(image.Object, "spec", "sourceType"); got != ImageSourceTypeUpload {
+					t.Errorf("UploadImage() source type = %q, want %q", got, ImageSourceTypeUpload)
+				}
+				if got := getNestedString(image.Object, "spec", "displayName"); got != "disk.img" {
+					t.Errorf("UploadImage() display name = %q, want %q", got, "disk.img")
+				}
+			}
+
+			standIn.mu.Lock()
+			defer standIn.mu.Unlock()
+			if string(standIn.uploaded) != string(content) {
+				t.Errorf("uploaded chunk = %q, want %q", standIn.uploaded, content)
+			}
+			if standIn.deleted != tt.wantDeleted {
+				t.Errorf("image deleted = %v, want %v", standIn.deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
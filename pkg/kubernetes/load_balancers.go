@@ -0,0 +1,286 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// LoadBalancerWorkloadTypeVM is the workload type of load balancers in front of virtual machines.
+const LoadBalancerWorkloadTypeVM = "vm"
+
+// IP address management modes of a load balancer
+const (
+	LoadBalancerIPAMPool = "pool"
+	LoadBalancerIPAMDHCP = "dhcp"
+)
+
+// LoadBalancerDetails is a load balancer together with the running VMs matching its backend selector.
+type LoadBalancerDetails struct {
+	LoadBalancer *unstructured.Unstructured
+	// BackendVMs lists the matching VMs as "name (address)".
+	BackendVMs []string
+}
+
+// LoadBalancerListener is a port a load balancer exposes and the VM port it forwards to.
+type LoadBalancerListener struct {
+	Name        string
+	Port        int
+	Protocol    string
+	BackendPort int
+}
+
+// LoadBalancerCreateOptions describes a load balancer in front of the VMs matching a selector.
+type LoadBalancerCreateOptions struct {
+	Namespace   string
+	Name        string
+	Description string
+	// IPAM is pool to allocate the address from an IP pool, or dhcp to request it from a DHCP server.
+	IPAM string
+	// IPPool is the pool to allocate from; when empty, Harvester picks the pool whose selector matches.
+	IPPool    string
+	Listeners []LoadBalancerListener
+	// BackendSelector maps VM label keys to the values they may have; a VM must match all keys.
+	BackendSelector map[string][]string
+}
+
+// ParseLoadBalancerListener parses a listener given as name:port:backendPort, optionally followed by
+// :protocol (TCP or UDP, defaults to TCP).
+func ParseLoadBalancerListener(value string) (LoadBalancerListener, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 && len(parts) != 4 {
+		return LoadBalancerListener{}, fmt.Errorf("invalid listener %q, expected name:port:backendPort[:protocol]", value)
+	}
+
+	listener := LoadBalancerListener{Name: parts[0], Protocol: "TCP"}
+	var err error
+	if listener.Port, err = strconv.Atoi(parts[1]); err != nil {
+		return LoadBalancerListener{}, fmt.Errorf("invalid port in listener %q: %w", value, err)
+	}
+	if listener.BackendPort, err = strconv.Atoi(parts[2]); err != nil {
+		return LoadBalancerListener{}, fmt.Errorf("invalid backend port in listener %q: %w", value, err)
+	}
+	if len(parts) == 4 {
+		listener.Protocol = strings.ToUpper(parts[3])
+	}
+	return listener, nil
+}
+
+// ParseLoadBalancerBackendSelector parses key=value terms into a backend server selector.
+// Repeating a key allows several values for it.
+func ParseLoadBalancerBackendSelector(terms []string) (map[string][]string, error) {
+	selector := map[string][]string{}
+	for _, term := range terms {
+		key, value, ok := strings.Cut(term, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid selector term %q, expected key=value", term)
+		}
+		selector[key] = append(selector[key], value)
+	}
+	return selector, nil
+}
+
+// GetLoadBalancer retrieves a load balancer with the VMs matching its backend selector.
+func (h *ResourceHandler) GetLoadBalancer(ctx context.Context, namespace, name string) (*LoadBalancerDetails, error) {
+	lb, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeLoadBalancer], namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	vmis, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeVMIs], namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list virtual machine instances: %w", err)
+	}
+
+	return &LoadBalancerDetails{LoadBalancer: lb, BackendVMs: loadBalancerBackendVMs(lb, vmis.Items)}, nil
+}
+
+// ListLoadBalancers lists load balancers with the VMs matching their backend selectors.
+func (h *ResourceHandler) ListLoadBalancers(ctx context.Context, namespace string) ([]LoadBalancerDetails, error) {
+	list, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeLoadBalancers], namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	vmis, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeVMIs], namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list virtual machine instances: %w", err)
+	}
+
+	lbs := make([]LoadBalancerDetails, 0, len(list.Items))
+	for i := range list.Items {
+		lb := &list.Items[i]
+		lbs = append(lbs, LoadBalancerDetails{LoadBalancer: lb, BackendVMs: loadBalancerBackendVMs(lb, vmis.Items)})
+	}
+
+	return lbs, nil
+}
+
+// loadBalancerBackendVMs returns the running VMs in the load balancer's namespace that match its
+// backend server selector, as "name (address)".
+func loadBalancerBackendVMs(lb *unstructured.Unstructured, vmis []unstructured.Unstructured) []string {
+	selector := getNestedMap(lb.Object, "spec", "backendServerSelector")
+	if len(selector) == 0 {
+		return nil
+	}
+
+	var vms []string
+	for _, vmi := range vmis {
+		if vmi.GetNamespace() != lb.GetNamespace() || !matchesLoadBalancerSelector(vmi.GetLabels(), selector) {
+			continue
+		}
+		backend := vmi.GetName()
+		if address := vmiAddress(&vmi); address != "" {
+			backend = fmt.Sprintf("%s (%s)", backend, address)
+		}
+		vms = append(vms, backend)
+	}
+	return vms
+}
+
+// matchesLoadBalancerSelector reports whether labels have, for every key of the selector, one of its values.
+func matchesLoadBalancerSelector(labels map[string]string, selector map[string]interface{}) bool {
+	for key, valuesObj := range selector {
+		value, ok := labels[key]
+		if !ok {
+			return false
+		}
+		values, _ := valuesObj.([]interface{})
+		matched := len(values) == 0
+		for _, candidate := range values {
+			if candidate == value {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// vmiAddress returns the first IP address the VMI reports on its interfaces.
+func vmiAddress(vmi *unstructured.Unstructured) string {
+	interfaces, _, _ := unstructured.NestedSlice(vmi.Object, "status", "interfaces")
+	for _, ifaceObj := range interfaces {
+		iface, ok := ifaceObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if address := getNestedString(iface, "ipAddress"); address != "" {
+			return address
+		}
+	}
+	return ""
+}
+
+// CreateLoadBalancer creates a load balancer forwarding the listener ports to the VMs matching the backend selector.
+func (h *ResourceHandler) CreateLoadBalancer(ctx context.Context, opts LoadBalancerCreateOptions) (*unstructured.Unstructured, error) {
+	if opts.IPAM == "" {
+		opts.IPAM = LoadBalancerIPAMPool
+	}
+	switch opts.IPAM {
+	case LoadBalancerIPAMPool:
+		if opts.IPPool != "" {
+			if _, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeIPPool], "", opts.IPPool); err != nil {
+				return nil, fmt.Errorf("failed to get IP pool %s: %w", opts.IPPool, err)
+			}
+		}
+	case LoadBalancerIPAMDHCP:
+		if opts.IPPool != "" {
+			return nil, fmt.Errorf("an IP pool can only be used with pool IPAM")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported IPAM mode %q", opts.IPAM)
+	}
+
+	if len(opts.BackendSelector) == 0 {
+		return nil, fmt.Errorf("a backend selector is required to choose the VMs behind the load balancer")
+	}
+	if len(opts.Listeners) == 0 {
+		return nil, fmt.Errorf("at least one listener is required")
+	}
+
+	names := map[string]bool{}
+	ports := map[int]bool{}
+	var listeners []interface{}
+	for _, listener := range opts.Listeners {
+		if listener.Name == "" {
+			return nil, fmt.Errorf("listener names cannot be empty")
+		}
+		if names[listener.Name] {
+			return nil, fmt.Errorf("duplicate listener name %s", listener.Name)
+		}
+		if ports[listener.Port] {
+			return nil, fmt.Errorf("duplicate listener port %d", listener.Port)
+		}
+		if listener.Port < 1 || listener.Port > 65535 || listener.BackendPort < 1 || listener.BackendPort > 65535 {
+			return nil, fmt.Errorf("ports of listener %s must be between 1 and 65535", listener.Name)
+		}
+		if listener.Protocol != "TCP" && listener.Protocol != "UDP" {
+			return nil, fmt.Errorf("unsupported protocol %q of listener %s, must be TCP or UDP", listener.Protocol, listener.Name)
+		}
+		names[listener.Name] = true
+		ports[listener.Port] = true
+		listeners = append(listeners, map[string]interface{}{
+			"name":        listener.Name,
+			"port":        int64(listener.Port),
+			"protocol":    listener.Protocol,
+			"backendPort": int64(listener.BackendPort),
+		})
+	}
+
+	selector := map[string]interface{}{}
+	for key, values := range opts.BackendSelector {
+		var selectorValues []interface{}
+		for _, value := range values {
+			selectorValues = append(selectorValues, value)
+		}
+		selector[key] = selectorValues
+	}
+
+	spec := map[string]interface{}{
+		"workloadType":          LoadBalancerWorkloadTypeVM,
+		"ipam":                  opts.IPAM,
+		"listeners":             listeners,
+		"backendServerSelector": selector,
+	}
+	if opts.IPPool != "" {
+		spec["ipPool"] = opts.IPPool
+	}
+	if opts.Description != "" {
+		spec["description"] = opts.Description
+	}
+
+	lb := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "loadbalancer.harvesterhci.io/v1beta1",
+			"kind":       "LoadBalancer",
+			"metadata": map[string]interface{}{
+				"name":      opts.Name,
+				"namespace": opts.Namespace,
+			},
+			"spec": spec,
+		},
+	}
+
+	return h.CreateResource(ctx, ResourceTypeToGVR[ResourceTypeLoadBalancer], opts.Namespace, lb)
+}
+
+// ListIPPools lists IP pools sorted by name.
+func (h *ResourceHandler) ListIPPools(ctx context.Context) (*unstructured.UnstructuredList, error) {
+	list, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeIPPools], "")
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool {
+		return list.Items[i].GetName() < list.Items[j].GetName()
+	})
+	return list, nil
+}
@@ -0,0 +1,303 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Labels and annotations Harvester puts on the NetworkAttachmentDefinitions of VM networks
+const (
+	labelNetworkClusterNetwork = "network.harvesterhci.io/clusternetwork"
+	labelNetworkType           = "network.harvesterhci.io/type"
+	labelNetworkVLANID         = "network.harvesterhci.io/vlan-id"
+	labelNetworkReady          = "network.harvesterhci.io/ready"
+	annotationNetworkRoute     = "network.harvesterhci.io/route"
+)
+
+// VM network types, as recorded in the type label of the NetworkAttachmentDefinition
+const (
+	VMNetworkTypeL2VLAN   = "L2VlanNetwork"
+	VMNetworkTypeUntagged = "UntaggedNetwork"
+)
+
+// Route modes of a VM network
+const (
+	NetworkRouteModeAuto   = "auto"
+	NetworkRouteModeManual = "manual"
+)
+
+// networkCNIVersion is the CNI spec version of the bridge configs the Harvester UI creates.
+const networkCNIVersion = "0.3.1"
+
+// NetworkDetails is a VM network together with the VMs attached to it.
+type NetworkDetails struct {
+	Network *unstructured.Unstructured
+	// VMs lists the "namespace/name" of the virtual machines attached to the network.
+	VMs []string
+}
+
+// NetworkConfig is the CNI configuration stored as JSON in the spec.config of a NetworkAttachmentDefinition.
+type NetworkConfig struct {
+	CNIVersion  string                 `json:"cniVersion"`
+	Name        string                 `json:"name"`
+	Type        string                 `json:"type"`
+	Bridge      string                 `json:"bridge"`
+	PromiscMode bool                   `json:"promiscMode"`
+	VLAN        int                    `json:"vlan,omitempty"`
+	IPAM        map[string]interface{} `json:"ipam"`
+}
+
+// NetworkRoute is the routing information Harvester keeps in the route annotation of a VM network.
+type NetworkRoute struct {
+	// Mode is auto when the route is learned through DHCP and manual when configured.
+	Mode         string `json:"mode"`
+	ServerIPAddr string `json:"serverIPAddr"`
+	CIDR         string `json:"cidr"`
+	Gateway      string `json:"gateway"`
+	Connectivity string `json:"connectivity,omitempty"`
+}
+
+// VMNetworkCreateOptions describes a VM network to be created on a cluster network.
+type VMNetworkCreateOptions struct {
+	Namespace      string
+	Name           string
+	ClusterNetwork string
+	// Type is VMNetworkTypeL2VLAN or VMNetworkTypeUntagged.
+	Type   string
+	VLANID int
+	// RouteMode is auto to learn the CIDR and gateway through DHCP, or manual to use the given ones.
+	// It defaults to manual when a CIDR is given and to auto otherwise.
+	RouteMode string
+	CIDR      string
+	Gateway   string
+}
+
+// parseNetworkConfig decodes the CNI configuration of a NetworkAttachmentDefinition.
+func parseNetworkConfig(nad *unstructured.Unstructured) (*NetworkConfig, error) {
+	config := &NetworkConfig{}
+	if err := json.Unmarshal([]byte(getNestedString(nad.Object, "spec", "config")), config); err != nil {
+		return nil, fmt.Errorf("invalid CNI config: %w", err)
+	}
+	return config, nil
+}
+
+// parseNetworkRoute decodes the route annotation of a NetworkAttachmentDefinition, nil if it has none.
+func parseNetworkRoute(nad *unstructured.Unstructured) (*NetworkRoute, error) {
+	value := nad.GetAnnotations()[annotationNetworkRoute]
+	if value == "" {
+		return nil, nil
+	}
+	route := &NetworkRoute{}
+	if err := json.Unmarshal([]byte(value), route); err != nil {
+		return nil, fmt.Errorf("invalid route annotation: %w", err)
+	}
+	return route, nil
+}
+
+// GetNetwork retrieves a VM network with the VMs attached to it.
+func (h *ResourceHandler) GetNetwork(ctx context.Context, namespace, name string) (*NetworkDetails, error) {
+	nad, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeNetwork], namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	vmsByNetwork, err := h.vmsByNetwork(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NetworkDetails{Network: nad, VMs: vmsByNetwork[namespace+"/"+name]}, nil
+}
+
+// ListNetworks lists VM networks with the VMs attached to them.
+func (h *ResourceHandler) ListNetworks(ctx context.Context, namespace string) ([]NetworkDetails, error) {
+	list, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeNetworks], namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	vmsByNetwork, err := h.vmsByNetwork(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	networks := make([]NetworkDetails, 0, len(list.Items))
+	for i := range list.Items {
+		nad := &list.Items[i]
+		networks = append(networks, NetworkDetails{Network: nad, VMs: vmsByNetwork[nad.GetNamespace()+"/"+nad.GetName()]})
+	}
+
+	return networks, nil
+}
+
+// vmsByNetwork maps "namespace/network" keys to the "namespace/name" of the virtual machines attached
+// to the network. VMs are listed in all namespaces since they may use networks of other namespaces.
+func (h *ResourceHandler) vmsByNetwork(ctx context.Context) (map[string][]string, error) {
+	vms, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeVMs], "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list virtual machines: %w", err)
+	}
+
+	vmsByNetwork := map[string][]string{}
+	for _, vm := range vms.Items {
+		networks, _, _ := unstructured.NestedSlice(vm.Object, "spec", "template", "spec", "networks")
+		for _, networkObj := range networks {
+			network, ok := networkObj.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			networkName := getNestedString(network, "multus", "networkName")
+			if networkName == "" {
+				continue
+			}
+			if !strings.Contains(networkName, "/") {
+				networkName = vm.GetNamespace() + "/" + networkName
+			}
+			vmsByNetwork[networkName] = append(vmsByNetwork[networkName], vm.GetNamespace()+"/"+vm.GetName())
+		}
+	}
+
+	return vmsByNetwork, nil
+}
+
+// CreateVMNetwork creates a VM network the same way the Harvester UI does: a NetworkAttachmentDefinition
+// with a bridge CNI config on the bridge of the cluster network, labeled with the cluster network and type.
+func (h *ResourceHandler) CreateVMNetwork(ctx context.Context, opts VMNetworkCreateOptions) (*unstructured.Unstructured, error) {
+	if _, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeClusterNetwork], "", opts.ClusterNetwork); err != nil {
+		return nil, fmt.Errorf("failed to get cluster network %s: %w", opts.ClusterNetwork, err)
+	}
+
+	config := NetworkConfig{
+		CNIVersion:  networkCNIVersion,
+		Name:        opts.Name,
+		Type:        "bridge",
+		Bridge:      opts.ClusterNetwork + "-br",
+		PromiscMode: true,
+		IPAM:        map[string]interface{}{},
+	}
+	labels := map[string]interface{}{
+		labelNetworkClusterNetwork: opts.ClusterNetwork,
+		labelNetworkReady:          "true",
+		labelNetworkType:           opts.Type,
+	}
+	annotations := map[string]interface{}{}
+
+	switch opts.Type {
+	case VMNetworkTypeL2VLAN:
+		if opts.VLANID < 1 || opts.VLANID > 4094 {
+			return nil, fmt.Errorf("invalid VLAN ID %d, must be between 1 and 4094", opts.VLANID)
+		}
+		config.VLAN = opts.VLANID
+		labels[labelNetworkVLANID] = strconv.Itoa(opts.VLANID)
+
+		route, err := buildNetworkRoute(opts)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(route)
+		if err != nil {
+			return nil, err
+		}
+		annotations[annotationNetworkRoute] = string(data)
+	case VMNetworkTypeUntagged:
+		if opts.VLANID != 0 {
+			return nil, fmt.Errorf("untagged networks cannot have a VLAN ID")
+		}
+		if opts.RouteMode != "" || opts.CIDR != "" || opts.Gateway != "" {
+			return nil, fmt.Errorf("route settings are only supported for VLAN networks")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported network type %q", opts.Type)
+	}
+
+	configData, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := map[string]interface{}{
+		"name":      opts.Name,
+		"namespace": opts.Namespace,
+		"labels":    labels,
+	}
+	if len(annotations) > 0 {
+		metadata["annotations"] = annotations
+	}
+
+	nad := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "k8s.cni.cncf.io/v1",
+			"kind":       "NetworkAttachmentDefinition",
+			"metadata":   metadata,
+			"spec": map[string]interface{}{
+				"config": string(configData),
+			},
+		},
+	}
+
+	return h.CreateResource(ctx, ResourceTypeToGVR[ResourceTypeNetwork], opts.Namespace, nad)
+}
+
+// buildNetworkRoute validates the route settings of a VLAN network and assembles its route annotation.
+func buildNetworkRoute(opts VMNetworkCreateOptions) (*NetworkRoute, error) {
+	mode := opts.RouteMode
+	if mode == "" {
+		mode = NetworkRouteModeAuto
+		if opts.CIDR != "" {
+			mode = NetworkRouteModeManual
+		}
+	}
+
+	route := &NetworkRoute{Mode: mode}
+	switch mode {
+	case NetworkRouteModeAuto:
+		if opts.CIDR != "" || opts.Gateway != "" {
+			return nil, fmt.Errorf("CIDR and gateway are learned through DHCP in auto route mode, use manual mode to set them")
+		}
+	case NetworkRouteModeManual:
+		_, ipNet, err := net.ParseCIDR(opts.CIDR)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", opts.CIDR, err)
+		}
+		gateway := net.ParseIP(opts.Gateway)
+		if gateway == nil {
+			return nil, fmt.Errorf("invalid gateway %q", opts.Gateway)
+		}
+		if !ipNet.Contains(gateway) {
+			return nil, fmt.Errorf("gateway %s is not in CIDR %s", opts.Gateway, opts.CIDR)
+		}
+		route.CIDR = opts.CIDR
+		route.Gateway = opts.Gateway
+	default:
+		return nil, fmt.Errorf("unsupported route mode %q", mode)
+	}
+
+	return route, nil
+}
+
+// DeleteVMNetwork deletes a VM network, refusing while any virtual machine is still attached to it.
+func (h *ResourceHandler) DeleteVMNetwork(ctx context.Context, namespace, name string) error {
+	gvr := ResourceTypeToGVR[ResourceTypeNetwork]
+	if _, err := h.GetResource(ctx, gvr, namespace, name); err != nil {
+		return fmt.Errorf("failed to get network %s/%s: %w", namespace, name, err)
+	}
+
+	vmsByNetwork, err := h.vmsByNetwork(ctx)
+	if err != nil {
+		return err
+	}
+	if vms := vmsByNetwork[namespace+"/"+name]; len(vms) > 0 {
+		return fmt.Errorf("network %s/%s is still used by virtual machines %s; remove it from their interfaces first", namespace, name, strings.Join(vms, ", "))
+	}
+
+	if err := h.DeleteResource(ctx, gvr, namespace, name); err != nil {
+		return fmt.Errorf("failed to delete network %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// annotationMaintainStatus is the node annotation Harvester uses to track maintenance mode.
+const annotationMaintainStatus = "harvesterhci.io/maintain-status"
+
+// Maintenance mode states recorded in the maintain status annotation
+const (
+	NodeMaintainStatusRunning   = "running"
+	NodeMaintainStatusCompleted = "completed"
+)
+
+// nodeMaintenancePollInterval is how often the VMs on a node are checked while it enters maintenance mode.
+const nodeMaintenancePollInterval = 5 * time.Second
+
+// NodeMaintenanceResult describes the progress of a node entering maintenance mode.
+type NodeMaintenanceResult struct {
+	// Node is the node after maintenance mode was requested.
+	Node *unstructured.Unstructured
+	// Migrated lists the "namespace/name" of the VMs that left the node and run elsewhere.
+	Migrated []string
+	// Stopped lists the VMs that left the node without running elsewhere, such as those Harvester shut
+	// down because they could not be migrated and force was set.
+	Stopped []string
+	// Blocking maps the VMs still on the node to the reason they keep it from completing maintenance.
+	Blocking map[string]string
+	// Completed is set once Harvester reports maintenance mode as completed.
+	Completed bool
+}
+
+// CordonNode marks a node unschedulable so that no new VMs or pods are placed on it.
+func (h *ResourceHandler) CordonNode(ctx context.Context, name string) (*unstructured.Unstructured, error) {
+	return h.updateNode(ctx, name, func(node *unstructured.Unstructured) error {
+		return unstructured.SetNestedField(node.Object, true, "spec", "unschedulable")
+	})
+}
+
+// UncordonNode marks a node schedulable again. Nodes in maintenance mode are refused, since
+// uncordoning them would let VMs return while maintenance is in progress.
+func (h *ResourceHandler) UncordonNode(ctx context.Context, name string) (*unstructured.Unstructured, error) {
+	return h.updateNode(ctx, name, func(node *unstructured.Unstructured) error {
+		if status := node.GetAnnotations()[annotationMaintainStatus]; status != "" {
+			return fmt.Errorf("node %s is in maintenance mode (%s), disable maintenance mode instead", name, status)
+		}
+		return unstructured.SetNestedField(node.Object, false, "spec", "unschedulable")
+	})
+}
+
+// EnableNodeMaintenanceMode puts a node into maintenance mode through Harvester's enableMaintenanceMode node
+// action. Harvester checks that the node can be drained, cordons and annotates it, and its maintenance controller
+// then live migrates the VMs to other nodes; with force, VMs that cannot be live migrated are shut down instead.
+// It waits up to timeout for Harvester to complete maintenance mode and reports the VMs still on the node.
+func (h *ResourceHandler) EnableNodeMaintenanceMode(ctx context.Context, name string, force bool, timeout time.Duration) (*NodeMaintenanceResult, error) {
+	node, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeNode], "", name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node %s: %w", name, err)
+	}
+
+	vmis, err := h.vmisOnNode(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &NodeMaintenanceResult{Node: node, Blocking: map[string]string{}}
+	body := map[string]interface{}{}
+	if force {
+		body["force"] = "true"
+	}
+	if err := h.DoHarvesterAction(ctx, "nodes/"+name, "enableMaintenanceMode", body); err != nil {
+		for i := range vmis {
+			if !hasTrueCondition(&vmis[i], "LiveMigratable") {
+				result.Blocking[vmis[i].GetNamespace()+"/"+vmis[i].GetName()] = liveMigratableReason(&vmis[i])
+			}
+		}
+		return result, err
+	}
+
+	// Harvester drains the node; VMs left over when the timeout expires are reported as blocking
+	var remaining []unstructured.Unstructured
+	err = wait.PollUntilContextTimeout(ctx, nodeMaintenancePollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		node, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeNode], "", name)
+		if err != nil {
+			return false, err
+		}
+		result.Node = node
+
+		remaining, err = h.vmisOnNode(ctx, name)
+		if err != nil {
+			return false, err
+		}
+		return node.GetAnnotations()[annotationMaintainStatus] == NodeMaintainStatusCompleted, nil
+	})
+	if err != nil && !wait.Interrupted(err) {
+		return result, err
+	}
+	result.Completed = result.Node.GetAnnotations()[annotationMaintainStatus] == NodeMaintainStatusCompleted
+
+	left := map[string]bool{}
+	for i := range remaining {
+		key := remaining[i].GetNamespace() + "/" + remaining[i].GetName()
+		left[key] = true
+		result.Blocking[key] = h.maintenanceBlockingReason(ctx, &remaining[i])
+	}
+	for _, vmi := range vmis {
+		key := vmi.GetNamespace() + "/" + vmi.GetName()
+		if left[key] {
+			continue
+		}
+		// VMs that left the node are running elsewhere when migrated and gone when Harvester shut them down
+		if _, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeVMI], vmi.GetNamespace(), vmi.GetName()); err == nil {
+			result.Migrated = append(result.Migrated, key)
+		} else {
+			result.Stopped = append(result.Stopped, key)
+		}
+	}
+	sort.Strings(result.Migrated)
+	sort.Strings(result.Stopped)
+
+	return result, nil
+}
+
+// DisableNodeMaintenanceMode takes a node out of maintenance mode through Harvester's disableMaintenanceMode
+// node action, which makes it schedulable again. VMs migrated away are not moved back.
+func (h *ResourceHandler) DisableNodeMaintenanceMode(ctx context.Context, name string) (*unstructured.Unstructured, error) {
+	gvr := ResourceTypeToGVR[ResourceTypeNode]
+	node, err := h.GetResource(ctx, gvr, "", name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node %s: %w", name, err)
+	}
+	if node.GetAnnotations()[annotationMaintainStatus] == "" {
+		return nil, fmt.Errorf("node %s is not in maintenance mode", name)
+	}
+
+	if err := h.DoHarvesterAction(ctx, "nodes/"+name, "disableMaintenanceMode", nil); err != nil {
+		return nil, err
+	}
+
+	return h.GetResource(ctx, gvr, "", name)
+}
+
+// updateNode applies mutate to the latest version of a node and saves it, retrying on conflicts.
+func (h *ResourceHandler) updateNode(ctx context.Context, name string, mutate func(node *unstructured.Unstructured) error) (*unstructured.Unstructured, error) {
+	gvr := ResourceTypeToGVR[ResourceTypeNode]
+	var updated *unstructured.Unstructured
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := h.GetResource(ctx, gvr, "", name)
+		if err != nil {
+			return err
+		}
+		if err := mutate(node); err != nil {
+			return err
+		}
+		updated, err = h.UpdateResource(ctx, gvr, "", node)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update node %s: %w", name, err)
+	}
+	return updated, nil
+}
+
+// vmisOnNode lists the VM instances in all namespaces that run on a node.
+func (h *ResourceHandler) vmisOnNode(ctx context.Context, name string) ([]unstructured.Unstructured, error) {
+	list, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeVMIs], "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list virtual machine instances: %w", err)
+	}
+
+	var vmis []unstructured.Unstructured
+	for _, vmi := range list.Items {
+		if getNestedString(vmi.Object, "status", "nodeName") == name {
+			vmis = append(vmis, vmi)
+		}
+	}
+	return vmis, nil
+}
+
+// liveMigratableReason explains why a VM instance cannot be live migrated.
+func liveMigratableReason(vmi *unstructured.Unstructured) string {
+	conditions, _, _ := unstructured.NestedSlice(vmi.Object, "status", "conditions")
+	for _, condObj := range conditions {
+		cond, ok := condObj.(map[string]interface{})
+		if !ok || getNestedString(cond, "type") != "LiveMigratable" {
+			continue
+		}
+		if message := getNestedString(cond, "message"); message != "" {
+			return "not live migratable: " + message
+		}
+		if reason := getNestedString(cond, "reason"); reason != "" {
+			return "not live migratable: " + reason
+		}
+	}
+	return "not live migratable"
+}
+
+// maintenanceBlockingReason explains why a VM instance is still on a node entering maintenance mode.
+func (h *ResourceHandler) maintenanceBlockingReason(ctx context.Context, vmi *unstructured.Unstructured) string {
+	if getNestedBool(vmi.Object, "status", "migrationState", "failed") {
+		return fmt.Sprintf("migration to node %s failed", getNestedString(vmi.Object, "status", "migrationState", "targetNode"))
+	}
+	active, err := h.findActiveMigration(ctx, vmi.GetNamespace(), vmi.GetName())
+	if err == nil && active != nil {
+		phase := getNestedString(active.Object, "status", "phase")
+		if phase == "" {
+			phase = "Pending"
+		}
+		return fmt.Sprintf("migration %s still in progress (%s)", active.GetName(), phase)
+	}
+	if !hasTrueCondition(vmi, "LiveMigratable") {
+		return liveMigratableReason(vmi)
+	}
+	return "still running on the node"
+}
@@ -2,6 +2,7 @@ package kubernetes
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -45,6 +46,15 @@ func (h *ResourceHandler) ListResources(ctx context.Context, gvr schema.GroupVer
 	return h.dynamicClient.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
 }
 
+// ListResourcesWithLabelSelector retrieves a list of resources of the specified type matching a label selector.
+func (h *ResourceHandler) ListResourcesWithLabelSelector(ctx context.Context, gvr schema.GroupVersionResource, namespace, labelSelector string) (*unstructured.UnstructuredList, error) {
+	opts := metav1.ListOptions{LabelSelector: labelSelector}
+	if namespace == "" {
+		return h.dynamicClient.Resource(gvr).List(ctx, opts)
+	}
+	return h.dynamicClient.Resource(gvr).Namespace(namespace).List(ctx, opts)
+}
+
 // GetResource retrieves a specific resource by name.
 func (h *ResourceHandler) GetResource(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
 	return h.dynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
@@ -65,6 +75,23 @@ func (h *ResourceHandler) DeleteResource(ctx context.Context, gvr schema.GroupVe
 	return h.dynamicClient.Resource(gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 }
 
+// DoSubresource sends a raw request to a subresource API such as subresources.kubevirt.io,
+// which is not served by the dynamic client. The response body is returned as-is.
+func (h *ResourceHandler) DoSubresource(ctx context.Context, method string, gvr schema.GroupVersionResource, namespace, name, subresource string, body interface{}) ([]byte, error) {
+	path := fmt.Sprintf("/apis/%s/%s/namespaces/%s/%s/%s/%s", gvr.Group, gvr.Version, namespace, gvr.Resource, name, subresource)
+
+	req := h.k8sClient.Discovery().RESTClient().Verb(method).AbsPath(path)
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		req = req.SetHeader("Content-Type", "application/json").Body(data)
+	}
+
+	return req.Do(ctx).Raw()
+}
+
 // IsNamespaced determines if a resource type is namespaced or cluster-scoped.
 func (h *ResourceHandler) IsNamespaced(gvr schema.GroupVersionResource) (bool, error) {
 	apiResourceList, err := h.k8sClient.Discovery().ServerResourcesForGroupVersion(gvr.GroupVersion().String())
@@ -96,15 +123,21 @@ func (h *ResourceHandler) FormatResourceList(list *unstructured.UnstructuredList
 		return formatDeploymentList(list)
 	case gvr.Resource == "virtualmachines" && gvr.Group == "kubevirt.io":
 		return formatVirtualMachineList(list)
-	case gvr.Resource == "networks" && gvr.Group == "network.harvesterhci.io":
+	case gvr.Resource == "network-attachment-definitions" && gvr.Group == "k8s.cni.cncf.io":
 		return formatNetworkList(list)
-	case gvr.Resource == "volumes" && gvr.Group == "storage.harvesterhci.io":
+	case gvr.Resource == "persistentvolumeclaims" && gvr.Group == "":
 		return formatVolumeList(list)
 	case gvr.Resource == "virtualmachineimages" && gvr.Group == "harvesterhci.io":
 		return formatImageList(list)
 	case gvr.Resource == "customresourcedefinitions" && gvr.Group == "apiextensions.k8s.io":
 		return formatCRDList(list)
 	default:
+		// Use a formatter registered for the kind if there is one
+		if len(list.Items) > 0 {
+			if formatter, exists := defaultRegistry.GetFormatter(list.Items[0].GetKind()); exists {
+				return formatter.FormatResourceList(list)
+			}
+		}
 		// Generic formatter for unsupported resource types
 		return formatGenericResourceList(list, gvr)
 	}
@@ -125,15 +158,19 @@ func (h *ResourceHandler) FormatResource(resource *unstructured.Unstructured, gv
 		return formatDeployment(resource)
 	case gvr.Resource == "virtualmachines" && gvr.Group == "kubevirt.io":
 		return formatVirtualMachine(resource)
-	case gvr.Resource == "networks" && gvr.Group == "network.harvesterhci.io":
+	case gvr.Resource == "network-attachment-definitions" && gvr.Group == "k8s.cni.cncf.io":
 		return formatNetwork(resource)
-	case gvr.Resource == "volumes" && gvr.Group == "storage.harvesterhci.io":
+	case gvr.Resource == "persistentvolumeclaims" && gvr.Group == "":
 		return formatVolume(resource)
 	case gvr.Resource == "virtualmachineimages" && gvr.Group == "harvesterhci.io":
 		return formatImage(resource)
 	case gvr.Resource == "customresourcedefinitions" && gvr.Group == "apiextensions.k8s.io":
 		return formatCRD(resource)
 	default:
+		// Use a formatter registered for the kind if there is one
+		if formatter, exists := defaultRegistry.GetFormatter(resource.GetKind()); exists {
+			return formatter.FormatResource(resource)
+		}
 		// Generic formatter for unsupported resource types
 		return formatGenericResource(resource, gvr)
 	}
@@ -0,0 +1,167 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
+)
+
+// longhornProvisioner is the CSI driver of Longhorn storage classes.
+const longhornProvisioner = "driver.longhorn.io"
+
+// Annotations marking the default storage class, which Harvester uses for new volumes and images
+const (
+	annotationDefaultStorageClass     = "storageclass.kubernetes.io/is-default-class"
+	annotationBetaDefaultStorageClass = "storageclass.beta.kubernetes.io/is-default-class"
+)
+
+// Defaults matching the Longhorn storage classes the Harvester UI creates
+const (
+	defaultStorageClassReplicas            = 3
+	defaultStorageClassStaleReplicaTimeout = 30
+)
+
+// StorageClassCreateOptions describes a Longhorn storage class to be created.
+type StorageClassCreateOptions struct {
+	Name string
+	// NumberOfReplicas is the number of Longhorn replicas of each volume; defaults to 3.
+	NumberOfReplicas int
+	// StaleReplicaTimeout is the number of minutes before a failed replica is cleaned up; defaults to 30.
+	StaleReplicaTimeout int
+	// DiskSelector and NodeSelector restrict replicas to Longhorn disks and nodes with all of these tags.
+	DiskSelector []string
+	NodeSelector []string
+	// Migratable allows live migration of VMs using volumes of the class.
+	Migratable           bool
+	DataLocality         string
+	ReclaimPolicy        string
+	VolumeBindingMode    string
+	AllowVolumeExpansion bool
+	// Default makes the new class the default storage class, replacing the current default.
+	Default bool
+}
+
+// ListStorageClasses lists storage classes sorted by name.
+func (h *ResourceHandler) ListStorageClasses(ctx context.Context) (*unstructured.UnstructuredList, error) {
+	list, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeStorageClasses], "")
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool {
+		return list.Items[i].GetName() < list.Items[j].GetName()
+	})
+	return list, nil
+}
+
+// CreateStorageClass creates a Longhorn storage class the same way the Harvester UI does.
+func (h *ResourceHandler) CreateStorageClass(ctx context.Context, opts StorageClassCreateOptions) (*unstructured.Unstructured, error) {
+	if opts.NumberOfReplicas == 0 {
+		opts.NumberOfReplicas = defaultStorageClassReplicas
+	}
+	if opts.StaleReplicaTimeout == 0 {
+		opts.StaleReplicaTimeout = defaultStorageClassStaleReplicaTimeout
+	}
+	if opts.NumberOfReplicas < 1 {
+		return nil, fmt.Errorf("invalid number of replicas %d", opts.NumberOfReplicas)
+	}
+	if opts.ReclaimPolicy == "" {
+		opts.ReclaimPolicy = "Delete"
+	}
+	if opts.VolumeBindingMode == "" {
+		opts.VolumeBindingMode = "Immediate"
+	}
+
+	parameters := map[string]interface{}{
+		"numberOfReplicas":    strconv.Itoa(opts.NumberOfReplicas),
+		"staleReplicaTimeout": strconv.Itoa(opts.StaleReplicaTimeout),
+		"migratable":          strconv.FormatBool(opts.Migratable),
+	}
+	if len(opts.DiskSelector) > 0 {
+		parameters["diskSelector"] = strings.Join(opts.DiskSelector, ",")
+	}
+	if len(opts.NodeSelector) > 0 {
+		parameters["nodeSelector"] = strings.Join(opts.NodeSelector, ",")
+	}
+	if opts.DataLocality != "" {
+		parameters["dataLocality"] = opts.DataLocality
+	}
+
+	metadata := map[string]interface{}{
+		"name": opts.Name,
+	}
+	if opts.Default {
+		metadata["annotations"] = map[string]interface{}{annotationDefaultStorageClass: "true"}
+	}
+
+	storageClass := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion":           "storage.k8s.io/v1",
+			"kind":                 "StorageClass",
+			"metadata":             metadata,
+			"provisioner":          longhornProvisioner,
+			"parameters":           parameters,
+			"reclaimPolicy":        opts.ReclaimPolicy,
+			"volumeBindingMode":    opts.VolumeBindingMode,
+			"allowVolumeExpansion": opts.AllowVolumeExpansion,
+		},
+	}
+
+	created, err := h.CreateResource(ctx, ResourceTypeToGVR[ResourceTypeStorageClass], "", storageClass)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Default {
+		if err := h.clearDefaultStorageClasses(ctx, opts.Name); err != nil {
+			return created, fmt.Errorf("storage class %s was created, but %w", opts.Name, err)
+		}
+	}
+
+	return created, nil
+}
+
+// clearDefaultStorageClasses removes the default mark from all storage classes except the given one,
+// so that there is a single default as the Harvester UI enforces.
+func (h *ResourceHandler) clearDefaultStorageClasses(ctx context.Context, keep string) error {
+	gvr := ResourceTypeToGVR[ResourceTypeStorageClass]
+	list, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeStorageClasses], "")
+	if err != nil {
+		return fmt.Errorf("failed to list storage classes: %w", err)
+	}
+
+	for _, item := range list.Items {
+		if item.GetName() == keep || !isDefaultStorageClass(&item) {
+			continue
+		}
+		name := item.GetName()
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			storageClass, err := h.GetResource(ctx, gvr, "", name)
+			if err != nil {
+				return err
+			}
+			annotations := storageClass.GetAnnotations()
+			delete(annotations, annotationDefaultStorageClass)
+			delete(annotations, annotationBetaDefaultStorageClass)
+			storageClass.SetAnnotations(annotations)
+			_, err = h.UpdateResource(ctx, gvr, "", storageClass)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to unset default storage class %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// isDefaultStorageClass reports whether a storage class is marked as the cluster default.
+func isDefaultStorageClass(storageClass *unstructured.Unstructured) bool {
+	annotations := storageClass.GetAnnotations()
+	return annotations[annotationDefaultStorageClass] == "true" || annotations[annotationBetaDefaultStorageClass] == "true"
+}
@@ -6,53 +6,139 @@ import (
 
 // Define constants for supported resource types
 const (
-	ResourceTypePod         = "pod"
-	ResourceTypePods        = "pods"
-	ResourceTypeDeployment  = "deployment"
-	ResourceTypeDeployments = "deployments"
-	ResourceTypeService     = "service"
-	ResourceTypeServices    = "services"
-	ResourceTypeNamespace   = "namespace"
-	ResourceTypeNamespaces  = "namespaces"
-	ResourceTypeNode        = "node"
-	ResourceTypeNodes       = "nodes"
-	ResourceTypeCRD         = "crd"
-	ResourceTypeCRDs        = "crds"
-	ResourceTypeVM          = "vm"
-	ResourceTypeVMs         = "vms"
-	ResourceTypeVolume      = "volume"
-	ResourceTypeVolumes     = "volumes"
-	ResourceTypeNetwork     = "network"
-	ResourceTypeNetworks    = "networks"
-	ResourceTypeImage       = "image"
-	ResourceTypeImages      = "images"
+	ResourceTypePod                    = "pod"
+	ResourceTypePods                   = "pods"
+	ResourceTypeDeployment             = "deployment"
+	ResourceTypeDeployments            = "deployments"
+	ResourceTypeService                = "service"
+	ResourceTypeServices               = "services"
+	ResourceTypeNamespace              = "namespace"
+	ResourceTypeNamespaces             = "namespaces"
+	ResourceTypeNode                   = "node"
+	ResourceTypeNodes                  = "nodes"
+	ResourceTypePVC                    = "pvc"
+	ResourceTypePVCs                   = "pvcs"
+	ResourceTypeCRD                    = "crd"
+	ResourceTypeCRDs                   = "crds"
+	ResourceTypeVM                     = "vm"
+	ResourceTypeVMs                    = "vms"
+	ResourceTypeVMI                    = "vmi"
+	ResourceTypeVMIs                   = "vmis"
+	ResourceTypeMigration              = "migration"
+	ResourceTypeMigrations             = "migrations"
+	ResourceTypeVolume                 = "volume"
+	ResourceTypeVolumes                = "volumes"
+	ResourceTypeNetwork                = "network"
+	ResourceTypeNetworks               = "networks"
+	ResourceTypeClusterNetwork         = "clusternetwork"
+	ResourceTypeClusterNetworks        = "clusternetworks"
+	ResourceTypeVlanConfig             = "vlanconfig"
+	ResourceTypeVlanConfigs            = "vlanconfigs"
+	ResourceTypeVlanStatus             = "vlanstatus"
+	ResourceTypeVlanStatuses           = "vlanstatuses"
+	ResourceTypeLoadBalancer           = "loadbalancer"
+	ResourceTypeLoadBalancers          = "loadbalancers"
+	ResourceTypeIPPool                 = "ippool"
+	ResourceTypeIPPools                = "ippools"
+	ResourceTypeImage                  = "image"
+	ResourceTypeImages                 = "images"
+	ResourceTypeKeyPair                = "keypair"
+	ResourceTypeKeyPairs               = "keypairs"
+	ResourceTypeVMBackup               = "vmbackup"
+	ResourceTypeVMBackups              = "vmbackups"
+	ResourceTypeVMRestore              = "vmrestore"
+	ResourceTypeVMRestores             = "vmrestores"
+	ResourceTypeSetting                = "setting"
+	ResourceTypeSettings               = "settings"
+	ResourceTypeSecret                 = "secret"
+	ResourceTypeSecrets                = "secrets"
+	ResourceTypeTemplate               = "template"
+	ResourceTypeTemplates              = "templates"
+	ResourceTypeTemplateVersion        = "templateversion"
+	ResourceTypeTemplateVersions       = "templateversions"
+	ResourceTypeVolumeSnapshot         = "volumesnapshot"
+	ResourceTypeVolumeSnapshots        = "volumesnapshots"
+	ResourceTypeVolumeSnapshotContent  = "volumesnapshotcontent"
+	ResourceTypeVolumeSnapshotContents = "volumesnapshotcontents"
+	ResourceTypeLonghornVolume         = "longhornvolume"
+	ResourceTypeLonghornVolumes        = "longhornvolumes"
+	ResourceTypeLonghornReplica        = "longhornreplica"
+	ResourceTypeLonghornReplicas       = "longhornreplicas"
+	ResourceTypeLonghornEngine         = "longhornengine"
+	ResourceTypeLonghornEngines        = "longhornengines"
+	ResourceTypeStorageClass           = "storageclass"
+	ResourceTypeStorageClasses         = "storageclasses"
 )
 
 // ResourceTypeToGVR maps friendly resource type names to GroupVersionResource
 var ResourceTypeToGVR = map[string]schema.GroupVersionResource{
 	// Core Kubernetes resources
-	ResourceTypePod:         {Group: "", Version: "v1", Resource: "pods"},
-	ResourceTypePods:        {Group: "", Version: "v1", Resource: "pods"},
-	ResourceTypeService:     {Group: "", Version: "v1", Resource: "services"},
-	ResourceTypeServices:    {Group: "", Version: "v1", Resource: "services"},
-	ResourceTypeNamespace:   {Group: "", Version: "v1", Resource: "namespaces"},
-	ResourceTypeNamespaces:  {Group: "", Version: "v1", Resource: "namespaces"},
-	ResourceTypeNode:        {Group: "", Version: "v1", Resource: "nodes"},
-	ResourceTypeNodes:       {Group: "", Version: "v1", Resource: "nodes"},
-	ResourceTypeDeployment:  {Group: "apps", Version: "v1", Resource: "deployments"},
-	ResourceTypeDeployments: {Group: "apps", Version: "v1", Resource: "deployments"},
-	ResourceTypeCRD:         {Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"},
-	ResourceTypeCRDs:        {Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"},
+	ResourceTypePod:            {Group: "", Version: "v1", Resource: "pods"},
+	ResourceTypePods:           {Group: "", Version: "v1", Resource: "pods"},
+	ResourceTypeService:        {Group: "", Version: "v1", Resource: "services"},
+	ResourceTypeServices:       {Group: "", Version: "v1", Resource: "services"},
+	ResourceTypeNamespace:      {Group: "", Version: "v1", Resource: "namespaces"},
+	ResourceTypeNamespaces:     {Group: "", Version: "v1", Resource: "namespaces"},
+	ResourceTypeNode:           {Group: "", Version: "v1", Resource: "nodes"},
+	ResourceTypeNodes:          {Group: "", Version: "v1", Resource: "nodes"},
+	ResourceTypePVC:            {Group: "", Version: "v1", Resource: "persistentvolumeclaims"},
+	ResourceTypePVCs:           {Group: "", Version: "v1", Resource: "persistentvolumeclaims"},
+	ResourceTypeSecret:         {Group: "", Version: "v1", Resource: "secrets"},
+	ResourceTypeSecrets:        {Group: "", Version: "v1", Resource: "secrets"},
+	ResourceTypeStorageClass:   {Group: "storage.k8s.io", Version: "v1", Resource: "storageclasses"},
+	ResourceTypeStorageClasses: {Group: "storage.k8s.io", Version: "v1", Resource: "storageclasses"},
+	ResourceTypeDeployment:     {Group: "apps", Version: "v1", Resource: "deployments"},
+	ResourceTypeDeployments:    {Group: "apps", Version: "v1", Resource: "deployments"},
+	ResourceTypeCRD:            {Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"},
+	ResourceTypeCRDs:           {Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"},
 
 	// Harvester-specific resources
-	ResourceTypeVM:       {Group: "kubevirt.io", Version: "v1", Resource: "virtualmachines"},
-	ResourceTypeVMs:      {Group: "kubevirt.io", Version: "v1", Resource: "virtualmachines"},
-	ResourceTypeVolume:   {Group: "storage.harvesterhci.io", Version: "v1beta1", Resource: "volumes"},
-	ResourceTypeVolumes:  {Group: "storage.harvesterhci.io", Version: "v1beta1", Resource: "volumes"},
-	ResourceTypeNetwork:  {Group: "network.harvesterhci.io", Version: "v1beta1", Resource: "networks"},
-	ResourceTypeNetworks: {Group: "network.harvesterhci.io", Version: "v1beta1", Resource: "networks"},
-	ResourceTypeImage:    {Group: "harvesterhci.io", Version: "v1beta1", Resource: "virtualmachineimages"},
-	ResourceTypeImages:   {Group: "harvesterhci.io", Version: "v1beta1", Resource: "virtualmachineimages"},
+	ResourceTypeVM:         {Group: "kubevirt.io", Version: "v1", Resource: "virtualmachines"},
+	ResourceTypeVMs:        {Group: "kubevirt.io", Version: "v1", Resource: "virtualmachines"},
+	ResourceTypeVMI:        {Group: "kubevirt.io", Version: "v1", Resource: "virtualmachineinstances"},
+	ResourceTypeVMIs:       {Group: "kubevirt.io", Version: "v1", Resource: "virtualmachineinstances"},
+	ResourceTypeMigration:  {Group: "kubevirt.io", Version: "v1", Resource: "virtualmachineinstancemigrations"},
+	ResourceTypeMigrations: {Group: "kubevirt.io", Version: "v1", Resource: "virtualmachineinstancemigrations"},
+	// Harvester volumes are PersistentVolumeClaims backed by Longhorn volumes
+	ResourceTypeVolume:  {Group: "", Version: "v1", Resource: "persistentvolumeclaims"},
+	ResourceTypeVolumes: {Group: "", Version: "v1", Resource: "persistentvolumeclaims"},
+	// Harvester VM networks are Multus NetworkAttachmentDefinitions
+	ResourceTypeNetwork:                {Group: "k8s.cni.cncf.io", Version: "v1", Resource: "network-attachment-definitions"},
+	ResourceTypeNetworks:               {Group: "k8s.cni.cncf.io", Version: "v1", Resource: "network-attachment-definitions"},
+	ResourceTypeClusterNetwork:         {Group: "network.harvesterhci.io", Version: "v1beta1", Resource: "clusternetworks"},
+	ResourceTypeClusterNetworks:        {Group: "network.harvesterhci.io", Version: "v1beta1", Resource: "clusternetworks"},
+	ResourceTypeVlanConfig:             {Group: "network.harvesterhci.io", Version: "v1beta1", Resource: "vlanconfigs"},
+	ResourceTypeVlanConfigs:            {Group: "network.harvesterhci.io", Version: "v1beta1", Resource: "vlanconfigs"},
+	ResourceTypeVlanStatus:             {Group: "network.harvesterhci.io", Version: "v1beta1", Resource: "vlanstatuses"},
+	ResourceTypeVlanStatuses:           {Group: "network.harvesterhci.io", Version: "v1beta1", Resource: "vlanstatuses"},
+	ResourceTypeLoadBalancer:           {Group: "loadbalancer.harvesterhci.io", Version: "v1beta1", Resource: "loadbalancers"},
+	ResourceTypeLoadBalancers:          {Group: "loadbalancer.harvesterhci.io", Version: "v1beta1", Resource: "loadbalancers"},
+	ResourceTypeIPPool:                 {Group: "loadbalancer.harvesterhci.io", Version: "v1beta1", Resource: "ippools"},
+	ResourceTypeIPPools:                {Group: "loadbalancer.harvesterhci.io", Version: "v1beta1", Resource: "ippools"},
+	ResourceTypeImage:                  {Group: "harvesterhci.io", Version: "v1beta1", Resource: "virtualmachineimages"},
+	ResourceTypeImages:                 {Group: "harvesterhci.io", Version: "v1beta1", Resource: "virtualmachineimages"},
+	ResourceTypeKeyPair:                {Group: "harvesterhci.io", Version: "v1beta1", Resource: "keypairs"},
+	ResourceTypeKeyPairs:               {Group: "harvesterhci.io", Version: "v1beta1", Resource: "keypairs"},
+	ResourceTypeVMBackup:               {Group: "harvesterhci.io", Version: "v1beta1", Resource: "virtualmachinebackups"},
+	ResourceTypeVMBackups:              {Group: "harvesterhci.io", Version: "v1beta1", Resource: "virtualmachinebackups"},
+	ResourceTypeVMRestore:              {Group: "harvesterhci.io", Version: "v1beta1", Resource: "virtualmachinerestores"},
+	ResourceTypeVMRestores:             {Group: "harvesterhci.io", Version: "v1beta1", Resource: "virtualmachinerestores"},
+	ResourceTypeSetting:                {Group: "harvesterhci.io", Version: "v1beta1", Resource: "settings"},
+	ResourceTypeSettings:               {Group: "harvesterhci.io", Version: "v1beta1", Resource: "settings"},
+	ResourceTypeTemplate:               {Group: "harvesterhci.io", Version: "v1beta1", Resource: "virtualmachinetemplates"},
+	ResourceTypeTemplates:              {Group: "harvesterhci.io", Version: "v1beta1", Resource: "virtualmachinetemplates"},
+	ResourceTypeTemplateVersion:        {Group: "harvesterhci.io", Version: "v1beta1", Resource: "virtualmachinetemplateversions"},
+	ResourceTypeTemplateVersions:       {Group: "harvesterhci.io", Version: "v1beta1", Resource: "virtualmachinetemplateversions"},
+	ResourceTypeVolumeSnapshot:         {Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshots"},
+	ResourceTypeVolumeSnapshots:        {Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshots"},
+	ResourceTypeVolumeSnapshotContent:  {Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshotcontents"},
+	ResourceTypeVolumeSnapshotContents: {Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshotcontents"},
+	ResourceTypeLonghornVolume:         {Group: "longhorn.io", Version: "v1beta2", Resource: "volumes"},
+	ResourceTypeLonghornVolumes:        {Group: "longhorn.io", Version: "v1beta2", Resource: "volumes"},
+	ResourceTypeLonghornReplica:        {Group: "longhorn.io", Version: "v1beta2", Resource: "replicas"},
+	ResourceTypeLonghornReplicas:       {Group: "longhorn.io", Version: "v1beta2", Resource: "replicas"},
+	ResourceTypeLonghornEngine:         {Group: "longhorn.io", Version: "v1beta2", Resource: "engines"},
+	ResourceTypeLonghornEngines:        {Group: "longhorn.io", Version: "v1beta2", Resource: "engines"},
 }
 
 // GVRToResourceType maps GroupVersionResource to friendly resource type names
@@ -62,12 +148,32 @@ var GVRToResourceType = map[schema.GroupVersionResource]string{
 	{Group: "", Version: "v1", Resource: "services"}:                                      ResourceTypeService,
 	{Group: "", Version: "v1", Resource: "namespaces"}:                                    ResourceTypeNamespace,
 	{Group: "", Version: "v1", Resource: "nodes"}:                                         ResourceTypeNode,
+	{Group: "", Version: "v1", Resource: "persistentvolumeclaims"}:                        ResourceTypePVC,
+	{Group: "", Version: "v1", Resource: "secrets"}:                                       ResourceTypeSecret,
+	{Group: "storage.k8s.io", Version: "v1", Resource: "storageclasses"}:                  ResourceTypeStorageClass,
 	{Group: "apps", Version: "v1", Resource: "deployments"}:                               ResourceTypeDeployment,
 	{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}: ResourceTypeCRD,
 
 	// Harvester-specific resources
-	{Group: "kubevirt.io", Version: "v1", Resource: "virtualmachines"}:               ResourceTypeVM,
-	{Group: "storage.harvesterhci.io", Version: "v1beta1", Resource: "volumes"}:      ResourceTypeVolume,
-	{Group: "network.harvesterhci.io", Version: "v1beta1", Resource: "networks"}:     ResourceTypeNetwork,
-	{Group: "harvesterhci.io", Version: "v1beta1", Resource: "virtualmachineimages"}: ResourceTypeImage,
+	{Group: "kubevirt.io", Version: "v1", Resource: "virtualmachines"}:                         ResourceTypeVM,
+	{Group: "kubevirt.io", Version: "v1", Resource: "virtualmachineinstances"}:                 ResourceTypeVMI,
+	{Group: "kubevirt.io", Version: "v1", Resource: "virtualmachineinstancemigrations"}:        ResourceTypeMigration,
+	{Group: "k8s.cni.cncf.io", Version: "v1", Resource: "network-attachment-definitions"}:      ResourceTypeNetwork,
+	{Group: "network.harvesterhci.io", Version: "v1beta1", Resource: "clusternetworks"}:        ResourceTypeClusterNetwork,
+	{Group: "network.harvesterhci.io", Version: "v1beta1", Resource: "vlanconfigs"}:            ResourceTypeVlanConfig,
+	{Group: "network.harvesterhci.io", Version: "v1beta1", Resource: "vlanstatuses"}:           ResourceTypeVlanStatus,
+	{Group: "loadbalancer.harvesterhci.io", Version: "v1beta1", Resource: "loadbalancers"}:     ResourceTypeLoadBalancer,
+	{Group: "loadbalancer.harvesterhci.io", Version: "v1beta1", Resource: "ippools"}:           ResourceTypeIPPool,
+	{Group: "harvesterhci.io", Version: "v1beta1", Resource: "virtualmachineimages"}:           ResourceTypeImage,
+	{Group: "harvesterhci.io", Version: "v1beta1", Resource: "keypairs"}:                       ResourceTypeKeyPair,
+	{Group: "harvesterhci.io", Version: "v1beta1", Resource: "virtualmachinebackups"}:          ResourceTypeVMBackup,
+	{Group: "harvesterhci.io", Version: "v1beta1", Resource: "virtualmachinerestores"}:         ResourceTypeVMRestore,
+	{Group: "harvesterhci.io", Version: "v1beta1", Resource: "settings"}:                       ResourceTypeSetting,
+	{Group: "harvesterhci.io", Version: "v1beta1", Resource: "virtualmachinetemplates"}:        ResourceTypeTemplate,
+	{Group: "harvesterhci.io", Version: "v1beta1", Resource: "virtualmachinetemplateversions"}: ResourceTypeTemplateVersion,
+	{Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshots"}:             ResourceTypeVolumeSnapshot,
+	{Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshotcontents"}:      ResourceTypeVolumeSnapshotContent,
+	{Group: "longhorn.io", Version: "v1beta2", Resource: "volumes"}:                            ResourceTypeLonghornVolume,
+	{Group: "longhorn.io", Version: "v1beta2", Resource: "replicas"}:                           ResourceTypeLonghornReplica,
+	{Group: "longhorn.io", Version: "v1beta2", Resource: "engines"}:                            ResourceTypeLonghornEngine,
 }
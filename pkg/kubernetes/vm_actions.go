@@ -0,0 +1,128 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// VMAction represents a power lifecycle action that can be performed on a virtual machine.
+type VMAction string
+
+// Supported virtual machine power lifecycle actions
+const (
+	VMActionStart   VMAction = "start"
+	VMActionStop    VMAction = "stop"
+	VMActionRestart VMAction = "restart"
+	VMActionPause   VMAction = "pause"
+	VMActionUnpause VMAction = "unpause"
+)
+
+// vmActionPollInterval is how often the VM state is checked while waiting for an action to complete.
+const vmActionPollInterval = 2 * time.Second
+
+// KubeVirt subresource API endpoints for virtual machines and their instances
+var (
+	vmSubresourceGVR  = schema.GroupVersionResource{Group: "subresources.kubevirt.io", Version: "v1", Resource: "virtualmachines"}
+	vmiSubresourceGVR = schema.GroupVersionResource{Group: "subresources.kubevirt.io", Version: "v1", Resource: "virtualmachineinstances"}
+)
+
+// VMActionResult describes the state of a virtual machine after a power lifecycle action.
+type VMActionResult struct {
+	// VM is the VirtualMachine resource after the action completed.
+	VM *unstructured.Unstructured
+	// VMIPhase is the phase of the VirtualMachineInstance, or "Absent" if no instance exists.
+	VMIPhase string
+}
+
+// PerformVMAction triggers a power lifecycle action on a virtual machine through the KubeVirt
+// subresource API and waits up to timeout for the VM to reach the requested state.
+func (h *ResourceHandler) PerformVMAction(ctx context.Context, namespace, name string, action VMAction, timeout time.Duration) (*VMActionResult, error) {
+	vmiGVR := ResourceTypeToGVR[ResourceTypeVMI]
+
+	// Remember the current instance so a restart can be told apart from the old one
+	var previousUID types.UID
+	vmi, err := h.GetResource(ctx, vmiGVR, namespace, name)
+	if err == nil {
+		previousUID = vmi.GetUID()
+	} else if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get virtual machine instance: %w", err)
+	}
+
+	subresourceGVR := vmSubresourceGVR
+	if action == VMActionPause || action == VMActionUnpause {
+		if previousUID == "" {
+			return nil, fmt.Errorf("virtual machine %s/%s is not running", namespace, name)
+		}
+		subresourceGVR = vmiSubresourceGVR
+	}
+
+	if _, err := h.DoSubresource(ctx, http.MethodPut, subresourceGVR, namespace, name, string(action), map[string]interface{}{}); err != nil {
+		return nil, fmt.Errorf("failed to %s virtual machine: %w", action, err)
+	}
+
+	phase := ""
+	err = wait.PollUntilContextTimeout(ctx, vmActionPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		vmi, err := h.GetResource(ctx, vmiGVR, namespace, name)
+		if apierrors.IsNotFound(err) {
+			phase = "Absent"
+			return action == VMActionStop, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		phase = getNestedString(vmi.Object, "status", "phase")
+		running := phase == "Running"
+		paused := hasTrueCondition(vmi, "Paused")
+
+		switch action {
+		case VMActionStart:
+			return running, nil
+		case VMActionRestart:
+			return running && vmi.GetUID() != previousUID, nil
+		case VMActionPause:
+			return paused, nil
+		case VMActionUnpause:
+			return running && !paused, nil
+		default:
+			return false, nil
+		}
+	})
+	if err != nil {
+		if phase == "" {
+			phase = "Unknown"
+		}
+		return nil, fmt.Errorf("virtual machine %s/%s did not complete %s (current instance phase: %s): %w", namespace, name, action, phase, err)
+	}
+
+	vm, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeVM], namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get virtual machine: %w", err)
+	}
+
+	return &VMActionResult{VM: vm, VMIPhase: phase}, nil
+}
+
+// hasTrueCondition reports whether the resource has a status condition of the given type set to True.
+func hasTrueCondition(res *unstructured.Unstructured, conditionType string) bool {
+	conditions, _, _ := unstructured.NestedSlice(res.Object, "status", "conditions")
+	for _, condObj := range conditions {
+		cond, ok := condObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if getNestedString(cond, "type") == conditionType && getNestedString(cond, "status") == "True" {
+			return true
+		}
+	}
+	return false
+}
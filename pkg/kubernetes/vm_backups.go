@@ -0,0 +1,186 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// VMBackupType distinguishes in-cluster snapshots from backups stored on the backup target.
+type VMBackupType string
+
+// Supported VirtualMachineBackup types
+const (
+	VMBackupTypeSnapshot VMBackupType = "snapshot"
+	VMBackupTypeBackup   VMBackupType = "backup"
+)
+
+// SettingBackupTarget is the name of the Harvester setting that configures the backup target.
+const SettingBackupTarget = "backup-target"
+
+// VM restore deletion policies for volumes of the replaced VM
+const (
+	VMRestoreDeletionPolicyRetain = "retain"
+	VMRestoreDeletionPolicyDelete = "delete"
+)
+
+// CreateVMBackup creates a VirtualMachineBackup of the given type for a VM. A name is generated when empty.
+func (h *ResourceHandler) CreateVMBackup(ctx context.Context, namespace, vmName, backupName string, backupType VMBackupType) (*unstructured.Unstructured, error) {
+	if _, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeVM], namespace, vmName); err != nil {
+		return nil, fmt.Errorf("failed to get virtual machine %s/%s: %w", namespace, vmName, err)
+	}
+
+	if backupType == VMBackupTypeBackup {
+		setting, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeSetting], "", SettingBackupTarget)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get backup target setting: %w", err)
+		}
+		if getNestedString(setting.Object, "value") == "" {
+			return nil, fmt.Errorf("backup target is not configured")
+		}
+	}
+
+	if backupName == "" {
+		backupName = fmt.Sprintf("%s-%s-%s", vmName, backupType, time.Now().UTC().Format("20060102150405"))
+	}
+
+	backup := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "harvesterhci.io/v1beta1",
+			"kind":       "VirtualMachineBackup",
+			"metadata": map[string]interface{}{
+				"name":      backupName,
+				"namespace": namespace,
+			},
+			"spec": map[string]interface{}{
+				"type": string(backupType),
+				"source": map[string]interface{}{
+					"apiGroup": "kubevirt.io",
+					"kind":     "VirtualMachine",
+					"name":     vmName,
+				},
+			},
+		},
+	}
+
+	return h.CreateResource(ctx, ResourceTypeToGVR[ResourceTypeVMBackup], namespace, backup)
+}
+
+// GetVMBackup retrieves a VirtualMachineBackup and verifies it is of the expected type.
+func (h *ResourceHandler) GetVMBackup(ctx context.Context, namespace, name string, backupType VMBackupType) (*unstructured.Unstructured, error) {
+	backup, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeVMBackup], namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if actual := vmBackupType(backup); actual != backupType {
+		return nil, fmt.Errorf("%s/%s is a VM %s, not a VM %s", namespace, name, actual, backupType)
+	}
+
+	return backup, nil
+}
+
+// ListVMBackups lists VirtualMachineBackups of the given type, optionally restricted to a single VM.
+func (h *ResourceHandler) ListVMBackups(ctx context.Context, namespace, vmName string, backupType VMBackupType) (*unstructured.UnstructuredList, error) {
+	list, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeVMBackups], namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := &unstructured.UnstructuredList{Object: list.Object}
+	for _, item := range list.Items {
+		if vmBackupType(&item) != backupType {
+			continue
+		}
+		if vmName != "" && getNestedString(item.Object, "spec", "source", "name") != vmName {
+			continue
+		}
+		filtered.Items = append(filtered.Items, item)
+	}
+
+	return filtered, nil
+}
+
+// DeleteVMBackup deletes a VirtualMachineBackup after verifying it is of the expected type.
+func (h *ResourceHandler) DeleteVMBackup(ctx context.Context, namespace, name string, backupType VMBackupType) error {
+	if _, err := h.GetVMBackup(ctx, namespace, name, backupType); err != nil {
+		return err
+	}
+
+	return h.DeleteResource(ctx, ResourceTypeToGVR[ResourceTypeVMBackup], namespace, name)
+}
+
+// RestoreVMBackup restores a VirtualMachineBackup. When newVMName is empty the source VM is replaced
+// in place, which requires it to be stopped; deletionPolicy decides what happens to the replaced volumes.
+func (h *ResourceHandler) RestoreVMBackup(ctx context.Context, namespace, backupName string, backupType VMBackupType, newVMName, deletionPolicy string) (*unstructured.Unstructured, error) {
+	backup, err := h.GetVMBackup(ctx, namespace, backupName, backupType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VM %s %s/%s: %w", backupType, namespace, backupName, err)
+	}
+	if !getNestedBool(backup.Object, "status", "readyToUse") {
+		return nil, fmt.Errorf("VM %s %s/%s is not ready to use", backupType, namespace, backupName)
+	}
+
+	if deletionPolicy == "" {
+		deletionPolicy = VMRestoreDeletionPolicyRetain
+	}
+	if deletionPolicy != VMRestoreDeletionPolicyRetain && deletionPolicy != VMRestoreDeletionPolicyDelete {
+		return nil, fmt.Errorf("invalid deletion policy %q, must be %q or %q", deletionPolicy, VMRestoreDeletionPolicyRetain, VMRestoreDeletionPolicyDelete)
+	}
+
+	newVM := newVMName != ""
+	targetName := newVMName
+	if newVM {
+		_, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeVM], namespace, newVMName)
+		if err == nil {
+			return nil, fmt.Errorf("virtual machine %s/%s already exists", namespace, newVMName)
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to check virtual machine %s/%s: %w", namespace, newVMName, err)
+		}
+	} else {
+		targetName = getNestedString(backup.Object, "spec", "source", "name")
+		_, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeVMI], namespace, targetName)
+		if err == nil {
+			return nil, fmt.Errorf("virtual machine %s/%s must be stopped before it can be restored in place", namespace, targetName)
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to check virtual machine instance %s/%s: %w", namespace, targetName, err)
+		}
+	}
+
+	restore := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "harvesterhci.io/v1beta1",
+			"kind":       "VirtualMachineRestore",
+			"metadata": map[string]interface{}{
+				"generateName": fmt.Sprintf("restore-%s-", backupName),
+				"namespace":    namespace,
+			},
+			"spec": map[string]interface{}{
+				"target": map[string]interface{}{
+					"apiGroup": "kubevirt.io",
+					"kind":     "VirtualMachine",
+					"name":     targetName,
+				},
+				"virtualMachineBackupName":      backupName,
+				"virtualMachineBackupNamespace": namespace,
+				"newVM":                         newVM,
+				"deletionPolicy":                deletionPolicy,
+			},
+		},
+	}
+
+	return h.CreateResource(ctx, ResourceTypeToGVR[ResourceTypeVMRestore], namespace, restore)
+}
+
+// vmBackupType returns the type of a VirtualMachineBackup, which defaults to backup when unset.
+func vmBackupType(backup *unstructured.Unstructured) VMBackupType {
+	if backupType := getNestedString(backup.Object, "spec", "type"); backupType != "" {
+		return VMBackupType(backupType)
+	}
+	return VMBackupTypeBackup
+}
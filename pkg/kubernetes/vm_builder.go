@@ -0,0 +1,380 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/yaml"
+)
+
+// Harvester annotations and labels attached to virtual machines
+const (
+	annotationVolumeClaimTemplates = "harvesterhci.io/volumeClaimTemplates"
+	annotationImageID              = "harvesterhci.io/imageId"
+	annotationSSHNames             = "harvesterhci.io/sshNames"
+	labelCreator                   = "harvesterhci.io/creator"
+	labelVMName                    = "harvesterhci.io/vmName"
+)
+
+// SettingOvercommitConfig is the name of the Harvester setting that configures resource overcommit ratios.
+const SettingOvercommitConfig = "overcommit-config"
+
+// cloudConfigHeader is the first line of cloud-init user data in the cloud-config format.
+const cloudConfigHeader = "#cloud-config"
+
+// overcommitConfig holds the overcommit-config setting, in percent of the requested resources that
+// may be allocated to VMs.
+type overcommitConfig struct {
+	CPU    int64 `json:"cpu"`
+	Memory int64 `json:"memory"`
+}
+
+// defaultOvercommitConfig matches the default of the overcommit-config setting.
+var defaultOvercommitConfig = overcommitConfig{CPU: 1600, Memory: 150}
+
+// defaultCloudInitUserData installs and enables the QEMU guest agent, matching the Harvester UI default.
+const defaultCloudInitUserData = `#cloud-config
+package_update: true
+packages:
+  - qemu-guest-agent
+runcmd:
+  - - systemctl
+    - enable
+    - --now
+    - qemu-guest-agent.service
+`
+
+// VMCreateOptions describes a virtual machine to be created from an image.
+type VMCreateOptions struct {
+	Name         string
+	Namespace    string
+	CPUCores     int64
+	Memory       string
+	Image        string
+	DiskSize     string
+	StorageClass string
+	// Networks are NetworkAttachmentDefinition references ("namespace/name" or "name").
+	// The pod network is used when empty.
+	Networks []string
+	// SSHKeys are KeyPair references ("namespace/name" or "name") whose public keys are injected via cloud-init.
+	SSHKeys  []string
+	UserData string
+}
+
+// CreateVirtualMachine builds a Harvester-compatible VirtualMachine from the options and creates it.
+func (h *ResourceHandler) CreateVirtualMachine(ctx context.Context, opts VMCreateOptions) (*unstructured.Unstructured, error) {
+	if opts.CPUCores <= 0 {
+		return nil, fmt.Errorf("CPU cores must be greater than zero")
+	}
+	memory, err := resource.ParseQuantity(opts.Memory)
+	if err != nil {
+		return nil, fmt.Errorf("invalid memory %q: %w", opts.Memory, err)
+	}
+	if _, err := resource.ParseQuantity(opts.DiskSize); err != nil {
+		return nil, fmt.Errorf("invalid disk size %q: %w", opts.DiskSize, err)
+	}
+
+	image, err := h.resolveImage(ctx, opts.Namespace, opts.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	storageClass := opts.StorageClass
+	if storageClass == "" {
+		storageClass = getNestedString(image.Object, "status", "storageClassName")
+	}
+	if storageClass == "" {
+		return nil, fmt.Errorf("image %s/%s has no storage class, please specify one", image.GetNamespace(), image.GetName())
+	}
+
+	publicKeys := make([]string, 0, len(opts.SSHKeys))
+	sshNames := make([]string, 0, len(opts.SSHKeys))
+	for _, ref := range opts.SSHKeys {
+		keyNamespace, keyName := splitNamespacedName(ref, opts.Namespace)
+		keyPair, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeKeyPair], keyNamespace, keyName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get SSH key pair %s/%s: %w", keyNamespace, keyName, err)
+		}
+		publicKeys = append(publicKeys, getNestedString(keyPair.Object, "spec", "publicKey"))
+		sshNames = append(sshNames, keyNamespace+"/"+keyName)
+	}
+
+	userData, err := buildCloudInitUserData(opts.UserData, publicKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	overcommit, err := h.getOvercommitConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cpuRequest, memoryRequest := vmResourceRequests(opts.CPUCores, memory, overcommit)
+
+	vm, err := buildVirtualMachine(opts, image, storageClass, userData, sshNames, cpuRequest, memoryRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.CreateResource(ctx, ResourceTypeToGVR[ResourceTypeVM], opts.Namespace, vm)
+}
+
+// resolveImage finds a VirtualMachineImage by resource name, falling back to its display name.
+func (h *ResourceHandler) resolveImage(ctx context.Context, namespace, ref string) (*unstructured.Unstructured, error) {
+	imageNamespace, imageName := splitNamespacedName(ref, namespace)
+	gvr := ResourceTypeToGVR[ResourceTypeImage]
+
+	image, err := h.GetResource(ctx, gvr, imageNamespace, imageName)
+	if err == nil {
+		return image, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get image %s/%s: %w", imageNamespace, imageName, err)
+	}
+
+	list, err := h.ListResources(ctx, gvr, imageNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images in namespace %s: %w", imageNamespace, err)
+	}
+	for i := range list.Items {
+		if getNestedString(list.Items[i].Object, "spec", "displayName") == imageName {
+			return &list.Items[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("image %s not found in namespace %s", imageName, imageNamespace)
+}
+
+// getOvercommitConfig reads the overcommit-config setting, falling back to its default ratios when
+// the setting or any of its ratios is not set.
+func (h *ResourceHandler) getOvercommitConfig(ctx context.Context) (overcommitConfig, error) {
+	setting, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeSetting], "", SettingOvercommitConfig)
+	if apierrors.IsNotFound(err) {
+		return defaultOvercommitConfig, nil
+	}
+	if err != nil {
+		return overcommitConfig{}, fmt.Errorf("failed to get %s setting: %w", SettingOvercommitConfig, err)
+	}
+	value := getNestedString(setting.Object, "value")
+	if value == "" {
+		value = getNestedString(setting.Object, "default")
+	}
+	if value == "" {
+		return defaultOvercommitConfig, nil
+	}
+
+	var config overcommitConfig
+	if err := json.Unmarshal([]byte(value), &config); err != nil {
+		return overcommitConfig{}, fmt.Errorf("failed to parse %s setting: %w", SettingOvercommitConfig, err)
+	}
+	if config.CPU <= 0 {
+		config.CPU = defaultOvercommitConfig.CPU
+	}
+	if config.Memory <= 0 {
+		config.Memory = defaultOvercommitConfig.Memory
+	}
+	return config, nil
+}
+
+// vmResourceRequests returns the CPU and memory requests of a VM with the given limits, scaled down by the
+// overcommit ratios the same way the Harvester UI does.
+func vmResourceRequests(cpuCores int64, memory resource.Quantity, overcommit overcommitConfig) (string, string) {
+	cpuRequest := resource.NewMilliQuantity(cpuCores*1000*100/overcommit.CPU, resource.DecimalSI)
+	memoryRequest := resource.NewQuantity(memory.Value()*100/overcommit.Memory/(1<<20)*(1<<20), resource.BinarySI)
+	return cpuRequest.String(), memoryRequest.String()
+}
+
+// buildVirtualMachine assembles the VirtualMachine object the same way the Harvester UI does,
+// with the root disk provisioned from the image through a volume claim template.
+func buildVirtualMachine(opts VMCreateOptions, image *unstructured.Unstructured, storageClass, userData string, sshNames []string, cpuRequest, memoryRequest string) (*unstructured.Unstructured, error) {
+	diskName := "disk-0"
+	claimName := fmt.Sprintf("%s-%s-%s", opts.Name, diskName, utilrand.String(5))
+
+	claimTemplates := []map[string]interface{}{
+		{
+			"metadata": map[string]interface{}{
+				"name": claimName,
+				"annotations": map[string]interface{}{
+					annotationImageID: image.GetNamespace() + "/" + image.GetName(),
+				},
+			},
+			"spec": map[string]interface{}{
+				"accessModes": []interface{}{"ReadWriteMany"},
+				"resources": map[string]interface{}{
+					"requests": map[string]interface{}{"storage": opts.DiskSize},
+				},
+				"volumeMode":       "Block",
+				"storageClassName": storageClass,
+			},
+		},
+	}
+	claimTemplatesJSON, err := json.Marshal(claimTemplates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode volume claim templates: %w", err)
+	}
+
+	annotations := map[string]interface{}{
+		annotationVolumeClaimTemplates: string(claimTemplatesJSON),
+	}
+	if len(sshNames) > 0 {
+		sshNamesJSON, err := json.Marshal(sshNames)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode SSH key names: %w", err)
+		}
+		annotations[annotationSSHNames] = string(sshNamesJSON)
+	}
+
+	// Networks and their matching interfaces
+	var networks, interfaces []interface{}
+	if len(opts.Networks) == 0 {
+		networks = append(networks, map[string]interface{}{
+			"name": "default",
+			"pod":  map[string]interface{}{},
+		})
+		interfaces = append(interfaces, map[string]interface{}{
+			"name":       "default",
+			"model":      "virtio",
+			"masquerade": map[string]interface{}{},
+		})
+	}
+	for i, ref := range opts.Networks {
+		netNamespace, netName := splitNamespacedName(ref, opts.Namespace)
+		nicName := fmt.Sprintf("nic-%d", i)
+		networks = append(networks, map[string]interface{}{
+			"name": nicName,
+			"multus": map[string]interface{}{
+				"networkName": netNamespace + "/" + netName,
+			},
+		})
+		interfaces = append(interfaces, map[string]interface{}{
+			"name":   nicName,
+			"model":  "virtio",
+			"bridge": map[string]interface{}{},
+		})
+	}
+
+	vm := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "kubevirt.io/v1",
+			"kind":       "VirtualMachine",
+			"metadata": map[string]interface{}{
+				"name":        opts.Name,
+				"namespace":   opts.Namespace,
+				"annotations": annotations,
+				"labels": map[string]interface{}{
+					labelCreator: "harvester",
+				},
+			},
+			"spec": map[string]interface{}{
+				"runStrategy": "RerunOnFailure",
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"labels": map[string]interface{}{
+							labelVMName: opts.Name,
+						},
+					},
+					"spec": map[string]interface{}{
+						"hostname":                      opts.Name,
+						"evictionStrategy":              "LiveMigrate",
+						"terminationGracePeriodSeconds": int64(120),
+						"domain": map[string]interface{}{
+							"machine": map[string]interface{}{"type": "q35"},
+							"cpu": map[string]interface{}{
+								"cores":   opts.CPUCores,
+								"sockets": int64(1),
+								"threads": int64(1),
+							},
+							"resources": map[string]interface{}{
+								"limits": map[string]interface{}{
+									"cpu":    fmt.Sprintf("%d", opts.CPUCores),
+									"memory": opts.Memory,
+								},
+								"requests": map[string]interface{}{
+									"cpu":    cpuRequest,
+									"memory": memoryRequest,
+								},
+							},
+							"devices": map[string]interface{}{
+								"disks": []interface{}{
+									map[string]interface{}{
+										"name":      diskName,
+										"bootOrder": int64(1),
+										"disk":      map[string]interface{}{"bus": "virtio"},
+									},
+									map[string]interface{}{
+										"name": "cloudinitdisk",
+										"disk": map[string]interface{}{"bus": "virtio"},
+									},
+								},
+								"interfaces": interfaces,
+							},
+						},
+						"networks": networks,
+						"volumes": []interface{}{
+							map[string]interface{}{
+								"name": diskName,
+								"persistentVolumeClaim": map[string]interface{}{
+									"claimName": claimName,
+								},
+							},
+							map[string]interface{}{
+								"name": "cloudinitdisk",
+								"cloudInitNoCloud": map[string]interface{}{
+									"userData": userData,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return vm, nil
+}
+
+// buildCloudInitUserData returns the cloud-init user data with the given SSH public keys authorized.
+func buildCloudInitUserData(userData string, publicKeys []string) (string, error) {
+	if userData == "" {
+		userData = defaultCloudInitUserData
+	}
+	if len(publicKeys) == 0 {
+		return userData, nil
+	}
+
+	// Only cloud-config user data can be merged with the keys; scripts and MIME archives cannot
+	if header, _, _ := strings.Cut(userData, "\n"); strings.TrimSpace(header) != cloudConfigHeader {
+		return "", fmt.Errorf("SSH keys can only be added to cloud-init user data in the cloud-config format, which starts with a %q line; add the keys to the user data instead", cloudConfigHeader)
+	}
+
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(userData), &config); err != nil {
+		return "", fmt.Errorf("failed to parse cloud-init user data: %w", err)
+	}
+
+	authorizedKeys, _ := config["ssh_authorized_keys"].([]interface{})
+	for _, key := range publicKeys {
+		authorizedKeys = append(authorizedKeys, key)
+	}
+	config["ssh_authorized_keys"] = authorizedKeys
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cloud-init user data: %w", err)
+	}
+
+	return cloudConfigHeader + "\n" + string(data), nil
+}
+
+// splitNamespacedName splits a "namespace/name" reference, using defaultNamespace when none is given.
+func splitNamespacedName(ref, defaultNamespace string) (string, string) {
+	if namespace, name, found := strings.Cut(ref, "/"); found {
+		return namespace, name
+	}
+	return defaultNamespace, ref
+}
@@ -0,0 +1,336 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+)
+
+// VMCloneMethod selects how the disks of a cloned virtual machine are populated.
+type VMCloneMethod string
+
+// Supported VM clone methods
+const (
+	// VMCloneMethodCSI clones every volume claim directly through CSI volume cloning.
+	VMCloneMethodCSI VMCloneMethod = "csi"
+	// VMCloneMethodSnapshot takes a VolumeSnapshot of every volume claim and restores it into the new claim.
+	VMCloneMethodSnapshot VMCloneMethod = "snapshot"
+	// VMCloneMethodNone provisions image-based disks from their image again and creates other disks blank.
+	VMCloneMethodNone VMCloneMethod = "none"
+)
+
+// Runtime annotations of the source VM that must not be carried over to a clone
+var vmCloneDroppedAnnotations = []string{
+	"harvesterhci.io/mac-address",
+	"harvesterhci.io/timestamp",
+	"network.harvesterhci.io/ips",
+}
+
+// cloudInitHostnamePattern matches top-level hostname and fqdn keys in cloud-config user data.
+var cloudInitHostnamePattern = regexp.MustCompile(`(?m)^(hostname|fqdn):[ \t]*["']?([^"'\s]*)["']?[ \t]*$`)
+
+// VMCloneOptions describes a clone of an existing virtual machine.
+type VMCloneOptions struct {
+	SourceNamespace string
+	SourceName      string
+	Namespace       string
+	Name            string
+	Method          VMCloneMethod
+	// Start runs the clone once it is created; clones are created stopped otherwise.
+	Start bool
+	// Timeout bounds how long to wait for volume snapshots to become ready.
+	Timeout time.Duration
+}
+
+// VMCloneResult describes a cloned virtual machine and how its disks were populated.
+type VMCloneResult struct {
+	VM *unstructured.Unstructured
+	// Volumes maps each new volume claim to a description of its data source.
+	Volumes map[string]string
+	// Snapshots lists the VolumeSnapshots taken to clone the disks.
+	Snapshots []string
+}
+
+// CloneVirtualMachine copies a virtual machine to a new name and namespace. The clone gets new MAC addresses
+// and hostname; its cloud-init instance ID changes as well since KubeVirt derives it from the VM name and namespace.
+func (h *ResourceHandler) CloneVirtualMachine(ctx context.Context, opts VMCloneOptions) (*VMCloneResult, error) {
+	if opts.Namespace == "" {
+		opts.Namespace = opts.SourceNamespace
+	}
+	if opts.Method == "" {
+		opts.Method = VMCloneMethodCSI
+	}
+	if opts.Method != VMCloneMethodCSI && opts.Method != VMCloneMethodSnapshot && opts.Method != VMCloneMethodNone {
+		return nil, fmt.Errorf("invalid clone method %q, must be %q, %q or %q", opts.Method, VMCloneMethodCSI, VMCloneMethodSnapshot, VMCloneMethodNone)
+	}
+	if opts.Method != VMCloneMethodNone && opts.Namespace != opts.SourceNamespace {
+		return nil, fmt.Errorf("disks can only be cloned within namespace %s, use clone method %q to clone the VM into namespace %s", opts.SourceNamespace, VMCloneMethodNone, opts.Namespace)
+	}
+
+	source, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeVM], opts.SourceNamespace, opts.SourceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get virtual machine %s/%s: %w", opts.SourceNamespace, opts.SourceName, err)
+	}
+	if _, found, _ := unstructured.NestedSlice(source.Object, "spec", "dataVolumeTemplates"); found {
+		return nil, fmt.Errorf("virtual machine %s/%s uses data volume templates, which cannot be cloned", opts.SourceNamespace, opts.SourceName)
+	}
+
+	annotations := source.GetAnnotations()
+	for key := range annotations {
+		if strings.HasPrefix(key, "kubevirt.io/") {
+			delete(annotations, key)
+		}
+	}
+	for _, key := range vmCloneDroppedAnnotations {
+		delete(annotations, key)
+	}
+
+	vm := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "kubevirt.io/v1",
+		"kind":       "VirtualMachine",
+		"metadata": map[string]interface{}{
+			"name":      opts.Name,
+			"namespace": opts.Namespace,
+		},
+		"spec": getNestedMap(source.Object, "spec"),
+	}}
+	vm.SetAnnotations(annotations)
+	vm.SetLabels(source.GetLabels())
+
+	if err := unstructured.SetNestedField(vm.Object, opts.Name, "spec", "template", "metadata", "labels", labelVMName); err != nil {
+		return nil, err
+	}
+	if err := unstructured.SetNestedField(vm.Object, opts.Name, "spec", "template", "spec", "hostname"); err != nil {
+		return nil, err
+	}
+	runStrategy := "Halted"
+	if opts.Start {
+		runStrategy = "RerunOnFailure"
+	}
+	unstructured.RemoveNestedField(vm.Object, "spec", "running")
+	if err := unstructured.SetNestedField(vm.Object, runStrategy, "spec", "runStrategy"); err != nil {
+		return nil, err
+	}
+
+	if err := removeHotplugVolumes(vm); err != nil {
+		return nil, err
+	}
+	if err := removeVMMacAddresses(vm); err != nil {
+		return nil, err
+	}
+	if err := qualifyVMNetworks(vm, opts.SourceNamespace); err != nil {
+		return nil, err
+	}
+	if err := h.inlineCloudInitSecrets(ctx, vm, opts.SourceNamespace); err != nil {
+		return nil, err
+	}
+	if err := regenerateCloudInitHostname(vm, opts.Name); err != nil {
+		return nil, err
+	}
+
+	// Check the target name before any snapshot is taken for the clone
+	_, err = h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeVM], opts.Namespace, opts.Name)
+	if err == nil {
+		return nil, fmt.Errorf("virtual machine %s/%s already exists", opts.Namespace, opts.Name)
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get virtual machine %s/%s: %w", opts.Namespace, opts.Name, err)
+	}
+
+	result := &VMCloneResult{Volumes: map[string]string{}}
+	if err := h.cloneVMClaims(ctx, source, vm, opts, result); err != nil {
+		return nil, h.deleteCloneSnapshots(ctx, opts.SourceNamespace, result.Snapshots, err)
+	}
+
+	created, err := h.CreateResource(ctx, ResourceTypeToGVR[ResourceTypeVM], opts.Namespace, vm)
+	if err != nil {
+		err = fmt.Errorf("failed to create virtual machine %s/%s: %w", opts.Namespace, opts.Name, err)
+		return nil, h.deleteCloneSnapshots(ctx, opts.SourceNamespace, result.Snapshots, err)
+	}
+	result.VM = created
+
+	return result, nil
+}
+
+// deleteCloneSnapshots deletes the VolumeSnapshots taken for a clone that failed and returns cause,
+// extended with any snapshots that could not be deleted.
+func (h *ResourceHandler) deleteCloneSnapshots(ctx context.Context, namespace string, snapshots []string, cause error) error {
+	var leaked []string
+	for _, snapshot := range snapshots {
+		if err := h.DeleteResource(ctx, ResourceTypeToGVR[ResourceTypeVolumeSnapshot], namespace, snapshot); err != nil && !apierrors.IsNotFound(err) {
+			leaked = append(leaked, fmt.Sprintf("%s/%s: %v", namespace, snapshot, err))
+		}
+	}
+	if len(leaked) > 0 {
+		return fmt.Errorf("%w (failed to delete volume snapshots %s)", cause, strings.Join(leaked, "; "))
+	}
+	return cause
+}
+
+// cloneVMClaims describes every volume claim of the clone by a volume claim template, which the Harvester VM
+// controller turns into claims. Depending on the clone method the templates are populated from the source claims.
+func (h *ResourceHandler) cloneVMClaims(ctx context.Context, source, vm *unstructured.Unstructured, opts VMCloneOptions, result *VMCloneResult) error {
+	volumes, _, _ := unstructured.NestedSlice(source.Object, "spec", "template", "spec", "volumes")
+
+	var claimTemplates []map[string]interface{}
+	for _, volumeObj := range volumes {
+		volume, ok := volumeObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		claimName := getNestedString(volume, "persistentVolumeClaim", "claimName")
+		if claimName == "" || isHotplugVolume(volume) {
+			continue
+		}
+
+		pvc, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypePVC], opts.SourceNamespace, claimName)
+		if err != nil {
+			return fmt.Errorf("failed to get volume claim %s/%s: %w", opts.SourceNamespace, claimName, err)
+		}
+		claimTemplate := pvcClaimTemplate(pvc)
+
+		switch opts.Method {
+		case VMCloneMethodCSI:
+			claimTemplate["spec"].(map[string]interface{})["dataSource"] = map[string]interface{}{
+				"kind": "PersistentVolumeClaim",
+				"name": claimName,
+			}
+		case VMCloneMethodSnapshot:
+			snapshot, err := h.snapshotClaim(ctx, opts.SourceNamespace, claimName, opts.Timeout, result)
+			if err != nil {
+				return err
+			}
+			claimTemplate["spec"].(map[string]interface{})["dataSource"] = map[string]interface{}{
+				"apiGroup": "snapshot.storage.k8s.io",
+				"kind":     "VolumeSnapshot",
+				"name":     snapshot,
+			}
+		}
+
+		claimTemplates = append(claimTemplates, claimTemplate)
+	}
+
+	claimTemplatesJSON, err := json.Marshal(claimTemplates)
+	if err != nil {
+		return fmt.Errorf("failed to encode volume claim templates: %w", err)
+	}
+	annotations := vm.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[annotationVolumeClaimTemplates] = string(claimTemplatesJSON)
+	vm.SetAnnotations(annotations)
+
+	oldClaims := map[string]string{}
+	for _, volumeObj := range volumes {
+		if volume, ok := volumeObj.(map[string]interface{}); ok {
+			oldClaims[getNestedString(volume, "name")] = getNestedString(volume, "persistentVolumeClaim", "claimName")
+		}
+	}
+	if err := renameVMClaimTemplates(vm); err != nil {
+		return err
+	}
+
+	clonedVolumes, _, _ := unstructured.NestedSlice(vm.Object, "spec", "template", "spec", "volumes")
+	for _, volumeObj := range clonedVolumes {
+		volume, ok := volumeObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		claimName := getNestedString(volume, "persistentVolumeClaim", "claimName")
+		if claimName == "" {
+			continue
+		}
+
+		oldClaim := oldClaims[getNestedString(volume, "name")]
+		switch opts.Method {
+		case VMCloneMethodNone:
+			result.Volumes[claimName] = fmt.Sprintf("new disk replacing %s", oldClaim)
+		default:
+			result.Volumes[claimName] = fmt.Sprintf("cloned from %s", oldClaim)
+		}
+	}
+
+	return nil
+}
+
+// snapshotClaim takes a VolumeSnapshot of a volume claim and waits until it is ready to use. The snapshot
+// is recorded in the result as soon as it exists, so that it can be cleaned up when the clone fails.
+func (h *ResourceHandler) snapshotClaim(ctx context.Context, namespace, claimName string, timeout time.Duration, result *VMCloneResult) (string, error) {
+	snapshot, err := h.CreateVolumeSnapshot(ctx, namespace, claimName, fmt.Sprintf("%s-clone-%s", claimName, utilrand.String(5)))
+	if err != nil {
+		return "", err
+	}
+	result.Snapshots = append(result.Snapshots, snapshot.GetName())
+
+	if _, err := h.WaitForVolumeSnapshot(ctx, namespace, snapshot.GetName(), timeout); err != nil {
+		return "", err
+	}
+
+	return snapshot.GetName(), nil
+}
+
+// qualifyVMNetworks adds the source namespace to multus network references without one,
+// so the clone keeps using the same networks when it is created in another namespace.
+func qualifyVMNetworks(vm *unstructured.Unstructured, namespace string) error {
+	networksPath := []string{"spec", "template", "spec", "networks"}
+	networks, found, _ := unstructured.NestedSlice(vm.Object, networksPath...)
+	if !found {
+		return nil
+	}
+
+	for _, networkObj := range networks {
+		network, ok := networkObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		networkName := getNestedString(network, "multus", "networkName")
+		if networkName != "" && !strings.Contains(networkName, "/") {
+			if err := unstructured.SetNestedField(network, namespace+"/"+networkName, "multus", "networkName"); err != nil {
+				return err
+			}
+		}
+	}
+	return unstructured.SetNestedSlice(vm.Object, networks, networksPath...)
+}
+
+// regenerateCloudInitHostname points hostname and fqdn keys in the cloud-config user data of a VM at its new name.
+func regenerateCloudInitHostname(vm *unstructured.Unstructured, name string) error {
+	volumesPath := []string{"spec", "template", "spec", "volumes"}
+	volumes, _, _ := unstructured.NestedSlice(vm.Object, volumesPath...)
+
+	for _, volumeObj := range volumes {
+		volume, ok := volumeObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, source := range []string{"cloudInitNoCloud", "cloudInitConfigDrive"} {
+			userData := getNestedString(volume, source, "userData")
+			if userData == "" {
+				continue
+			}
+
+			userData = cloudInitHostnamePattern.ReplaceAllStringFunc(userData, func(line string) string {
+				match := cloudInitHostnamePattern.FindStringSubmatch(line)
+				if match[1] == "fqdn" {
+					if _, domain, found := strings.Cut(match[2], "."); found {
+						return fmt.Sprintf("fqdn: %s.%s", name, domain)
+					}
+				}
+				return fmt.Sprintf("%s: %s", match[1], name)
+			})
+			if err := unstructured.SetNestedField(volume, userData, source, "userData"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return unstructured.SetNestedSlice(vm.Object, volumes, volumesPath...)
+}
@@ -0,0 +1,117 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	gwebsocket "github.com/gorilla/websocket"
+	"k8s.io/client-go/transport/websocket"
+)
+
+// consoleSubprotocol is the websocket subprotocol KubeVirt uses for raw serial console streams.
+const consoleSubprotocol = "plain.kubevirt.io"
+
+// maxConsoleBufferSize bounds how much serial output is kept in memory while reading the console.
+const maxConsoleBufferSize = 1024 * 1024
+
+// ansiEscapePattern matches terminal control sequences emitted on serial consoles.
+var ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b[()][0-9A-Za-z]|\x1b[=>]`)
+
+// ReadVMConsole connects to the serial console of a running virtual machine, optionally sends input,
+// and returns the last maxLines lines of output received within duration.
+func (h *ResourceHandler) ReadVMConsole(ctx context.Context, namespace, name, input string, duration time.Duration, maxLines int) (string, error) {
+	conn, err := h.openVMConsole(ctx, namespace, name)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	if input != "" {
+		if err := conn.WriteMessage(gwebsocket.BinaryMessage, []byte(input)); err != nil {
+			return "", fmt.Errorf("failed to send console input: %w", err)
+		}
+	}
+
+	deadline := time.Now().Add(duration)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return "", fmt.Errorf("failed to set console read deadline: %w", err)
+	}
+
+	var output bytes.Buffer
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				break
+			}
+			if gwebsocket.IsCloseError(err, gwebsocket.CloseNormalClosure, gwebsocket.CloseGoingAway) {
+				break
+			}
+			return "", fmt.Errorf("failed to read console output: %w", err)
+		}
+
+		output.Write(data)
+		if output.Len() > maxConsoleBufferSize {
+			output.Next(output.Len() - maxConsoleBufferSize)
+		}
+	}
+
+	return lastConsoleLines(output.String(), maxLines), nil
+}
+
+// openVMConsole opens a websocket to the KubeVirt console subresource of a virtual machine instance.
+func (h *ResourceHandler) openVMConsole(ctx context.Context, namespace, name string) (*gwebsocket.Conn, error) {
+	if _, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeVMI], namespace, name); err != nil {
+		return nil, fmt.Errorf("failed to get virtual machine instance %s/%s, is the VM running? %w", namespace, name, err)
+	}
+
+	path := fmt.Sprintf("/apis/%s/%s/namespaces/%s/%s/%s/console", vmiSubresourceGVR.Group, vmiSubresourceGVR.Version, namespace, vmiSubresourceGVR.Resource, name)
+	consoleURL := h.k8sClient.Discovery().RESTClient().Get().AbsPath(path).URL()
+
+	rt, holder, err := websocket.RoundTripperFor(h.client.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create websocket transport: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, consoleURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create console request: %w", err)
+	}
+
+	conn, err := websocket.Negotiate(rt, holder, req, consoleSubprotocol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to console: %w", err)
+	}
+
+	return conn, nil
+}
+
+// lastConsoleLines strips terminal control sequences and returns the last maxLines non-empty lines.
+func lastConsoleLines(output string, maxLines int) string {
+	output = ansiEscapePattern.ReplaceAllString(output, "")
+	output = strings.ReplaceAll(output, "\r\n", "\n")
+	output = strings.ReplaceAll(output, "\r", "\n")
+
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if maxLines > 0 && len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,161 @@
+package kubernetes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gwebsocket "github.com/gorilla/websocket"
+	"github.com/starbops/harvester-mcp-server/pkg/client"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	testNamespace   = "default"
+	testVMName      = "vm1"
+	testBearerToken = "test-token"
+)
+
+// newTestResourceHandler returns a ResourceHandler whose Kubernetes and Harvester API requests are served by handler.
+func newTestResourceHandler(t *testing.T, handler http.Handler) *ResourceHandler {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config := &rest.Config{Host: server.URL, BearerToken: testBearerToken}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		t.Fatalf("failed to create clientset: %v", err)
+	}
+
+	h, err := NewResourceHandler(&client.Client{Clientset: clientset, Config: config, HarvesterAPIURL: server.URL})
+	if err != nil {
+		t.Fatalf("failed to create resource handler: %v", err)
+	}
+	return h
+}
+
+// consoleStandIn serves a running VMI and its console subresource. Each connection receives output,
+// followed by an echo of every message sent by the client, and is kept open until the client leaves.
+type consoleStandIn struct {
+	output string
+
+	mu          sync.Mutex
+	subprotocol string
+	input       []string
+}
+
+func (c *consoleStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/apis/kubevirt.io/v1/namespaces/" + testNamespace + "/virtualmachineinstances/" + testVMName:
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"apiVersion":"kubevirt.io/v1","kind":"VirtualMachineInstance","metadata":{"name":"` + testVMName + `","namespace":"` + testNamespace + `"}}`))
+	case "/apis/subresources.kubevirt.io/v1/namespaces/" + testNamespace + "/virtualmachineinstances/" + testVMName + "/console":
+		upgrader := gwebsocket.Upgrader{Subprotocols: []string{consoleSubprotocol}}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		c.mu.Lock()
+		c.subprotocol = conn.Subprotocol()
+		c.mu.Unlock()
+
+		if err := conn.WriteMessage(gwebsocket.BinaryMessage, []byte(c.output)); err != nil {
+			return
+		}
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			c.mu.Lock()
+			c.input = append(c.input, string(data))
+			c.mu.Unlock()
+			if err := conn.WriteMessage(gwebsocket.BinaryMessage, []byte("echo: "+string(data)+"\r\n")); err != nil {
+				return
+			}
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestReadVMConsoleSnapshot(t *testing.T) {
+	standIn := &consoleStandIn{output: "\x1b[2Jline 1\r\nline 2\r\n\r\n\x1b[1;32mline 3\x1b[0m\r\nlogin: "}
+	h := newTestResourceHandler(t, standIn)
+
+	duration := 300 * time.Millisecond
+	start := time.Now()
+	output, err := h.ReadVMConsole(context.Background(), testNamespace, testVMName, "", duration, 2)
+	if err != nil {
+		t.Fatalf("ReadVMConsole() error = %v", err)
+	}
+	elapsed := time.Since(start)
+
+	if want := "line 3\nlogin: "; output != want {
+		t.Errorf("ReadVMConsole() output = %q, want %q", output, want)
+	}
+	if elapsed < duration || elapsed > duration+5*time.Second {
+		t.Errorf("ReadVMConsole() returned after %s, want about %s", elapsed, duration)
+	}
+
+	standIn.mu.Lock()
+	defer standIn.mu.Unlock()
+	if standIn.subprotocol != consoleSubprotocol {
+		t.Errorf("negotiated subprotocol = %q, want %q", standIn.subprotocol, consoleSubprotocol)
+	}
+}
+
+func TestReadVMConsoleContextDeadline(t *testing.T) {
+	h := newTestResourceHandler(t, &consoleStandIn{output: "booting\r\n"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	output, err := h.ReadVMConsole(ctx, testNamespace, testVMName, "", time.Minute, 10)
+	if err != nil {
+		t.Fatalf("ReadVMConsole() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("ReadVMConsole() returned after %s, want the context deadline to bound the read", elapsed)
+	}
+	if output != "booting" {
+		t.Errorf("ReadVMConsole() output = %q, want %q", output, "booting")
+	}
+}
+
+func TestReadVMConsoleInput(t *testing.T) {
+	standIn := &consoleStandIn{output: "login: "}
+	h := newTestResourceHandler(t, standIn)
+
+	output, err := h.ReadVMConsole(context.Background(), testNamespace, testVMName, "root\r", 300*time.Millisecond, 10)
+	if err != nil {
+		t.Fatalf("ReadVMConsole() error = %v", err)
+	}
+	if !strings.Contains(output, "echo: root") {
+		t.Errorf("ReadVMConsole() output = %q, want it to contain the echoed input", output)
+	}
+
+	standIn.mu.Lock()
+	defer standIn.mu.Unlock()
+	if len(standIn.input) != 1 || standIn.input[0] != "root\r" {
+		t.Errorf("console received input %q, want %q", standIn.input, []string{"root\r"})
+	}
+}
+
+func TestReadVMConsoleVMNotRunning(t *testing.T) {
+	h := newTestResourceHandler(t, http.NotFoundHandler())
+
+	if _, err := h.ReadVMConsole(context.Background(), testNamespace, testVMName, "", time.Second, 10); err == nil {
+		t.Error("ReadVMConsole() error = nil, want an error for a missing VMI")
+	}
+}
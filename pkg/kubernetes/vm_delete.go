@@ -0,0 +1,142 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// annotationRemovedPVCs lists the volume claims the Harvester VM controller deletes together with a VM,
+// which is how the Harvester UI implements the removedDisks option when deleting a VM.
+const annotationRemovedPVCs = "harvesterhci.io/removedPersistentVolumeClaims"
+
+// vmDeletePollInterval is how often a deleted VM and its volume claims are checked.
+const vmDeletePollInterval = 2 * time.Second
+
+// VMDeleteOptions describes a virtual machine to be deleted and which of its disks to remove with it.
+type VMDeleteOptions struct {
+	Namespace string
+	Name      string
+	// RemoveDisks are disk names or volume claim names of the VM to delete; all other disks are kept.
+	RemoveDisks []string
+	// RemoveAllDisks deletes every volume claim of the VM.
+	RemoveAllDisks bool
+	// Timeout bounds how long to wait for the VM and the removed volume claims to be gone.
+	Timeout time.Duration
+}
+
+// VMDiskVolume is a disk of a virtual machine backed by a volume claim.
+type VMDiskVolume struct {
+	Disk      string
+	ClaimName string
+}
+
+// VMDeleteResult describes which volumes of a deleted virtual machine were removed and which remain.
+type VMDeleteResult struct {
+	Removed   []VMDiskVolume
+	Remaining []VMDiskVolume
+	// Pending are volumes marked for removal that still existed when the wait timed out,
+	// e.g. because the VM was still shutting down.
+	Pending []VMDiskVolume
+}
+
+// DeleteVirtualMachine deletes a virtual machine and the volume claims selected for removal,
+// keeping all its other volume claims.
+func (h *ResourceHandler) DeleteVirtualMachine(ctx context.Context, opts VMDeleteOptions) (*VMDeleteResult, error) {
+	gvr := ResourceTypeToGVR[ResourceTypeVM]
+	vm, err := h.GetResource(ctx, gvr, opts.Namespace, opts.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get virtual machine %s/%s: %w", opts.Namespace, opts.Name, err)
+	}
+
+	var volumes []VMDiskVolume
+	vmVolumes, _, _ := unstructured.NestedSlice(vm.Object, "spec", "template", "spec", "volumes")
+	for _, volumeObj := range vmVolumes {
+		volume, ok := volumeObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if claimName := getNestedString(volume, "persistentVolumeClaim", "claimName"); claimName != "" {
+			volumes = append(volumes, VMDiskVolume{Disk: getNestedString(volume, "name"), ClaimName: claimName})
+		}
+	}
+
+	remove := map[string]bool{}
+	for _, ref := range opts.RemoveDisks {
+		found := false
+		for _, volume := range volumes {
+			if ref == volume.Disk || ref == volume.ClaimName {
+				remove[volume.ClaimName] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("virtual machine %s/%s has no disk or volume claim named %s", opts.Namespace, opts.Name, ref)
+		}
+	}
+
+	var removedVolumes []VMDiskVolume
+	var removedClaims []string
+	result := &VMDeleteResult{}
+	for _, volume := range volumes {
+		if opts.RemoveAllDisks || remove[volume.ClaimName] {
+			removedVolumes = append(removedVolumes, volume)
+			removedClaims = append(removedClaims, volume.ClaimName)
+		} else {
+			result.Remaining = append(result.Remaining, volume)
+		}
+	}
+
+	// Record the volume claims to remove so the Harvester VM controller deletes them with the VM
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		vm, err := h.GetResource(ctx, gvr, opts.Namespace, opts.Name)
+		if err != nil {
+			return err
+		}
+		annotations := vm.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[annotationRemovedPVCs] = strings.Join(removedClaims, ",")
+		vm.SetAnnotations(annotations)
+		_, err = h.UpdateResource(ctx, gvr, opts.Namespace, vm)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark volumes of virtual machine %s/%s for removal: %w", opts.Namespace, opts.Name, err)
+	}
+
+	if err := h.DeleteResource(ctx, gvr, opts.Namespace, opts.Name); err != nil {
+		return nil, fmt.Errorf("failed to delete virtual machine %s/%s: %w", opts.Namespace, opts.Name, err)
+	}
+
+	// Wait for the VM to be gone and the removed volume claims to be deleted
+	pvcGVR := ResourceTypeToGVR[ResourceTypePVC]
+	_ = wait.PollUntilContextTimeout(ctx, vmDeletePollInterval, opts.Timeout, true, func(ctx context.Context) (bool, error) {
+		if _, err := h.GetResource(ctx, gvr, opts.Namespace, opts.Name); !apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		for _, volume := range removedVolumes {
+			if _, err := h.GetResource(ctx, pvcGVR, opts.Namespace, volume.ClaimName); !apierrors.IsNotFound(err) {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+
+	for _, volume := range removedVolumes {
+		if _, err := h.GetResource(ctx, pvcGVR, opts.Namespace, volume.ClaimName); apierrors.IsNotFound(err) {
+			result.Removed = append(result.Removed, volume)
+		} else {
+			result.Pending = append(result.Pending, volume)
+		}
+	}
+
+	return result, nil
+}
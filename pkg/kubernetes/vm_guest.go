@@ -0,0 +1,70 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// VMGuestInfo holds in-guest state reported by the QEMU guest agent of a running virtual machine.
+type VMGuestInfo struct {
+	// VMI is the VirtualMachineInstance, used for its interface addresses.
+	VMI *unstructured.Unstructured
+	// OSInfo is the response of the guestosinfo subresource.
+	OSInfo map[string]interface{}
+	// Users is the response of the userlist subresource, nil if it could not be retrieved.
+	Users []interface{}
+	// Filesystems is the response of the filesystemlist subresource, nil if it could not be retrieved.
+	Filesystems []interface{}
+	// Errors collects failures of the optional user and filesystem queries.
+	Errors []string
+}
+
+// GetVMGuestInfo queries the KubeVirt guest agent subresources of a running virtual machine instance.
+func (h *ResourceHandler) GetVMGuestInfo(ctx context.Context, namespace, name string) (*VMGuestInfo, error) {
+	vmi, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeVMI], namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get virtual machine instance %s/%s, is the VM running? %w", namespace, name, err)
+	}
+	if !hasTrueCondition(vmi, "AgentConnected") {
+		return nil, fmt.Errorf("the guest agent of virtual machine %s/%s is not connected", namespace, name)
+	}
+
+	info := &VMGuestInfo{VMI: vmi}
+
+	info.OSInfo, err = h.getGuestSubresource(ctx, namespace, name, "guestosinfo")
+	if err != nil {
+		return nil, err
+	}
+
+	if users, err := h.getGuestSubresource(ctx, namespace, name, "userlist"); err != nil {
+		info.Errors = append(info.Errors, err.Error())
+	} else {
+		info.Users, _, _ = unstructured.NestedSlice(users, "items")
+	}
+
+	if filesystems, err := h.getGuestSubresource(ctx, namespace, name, "filesystemlist"); err != nil {
+		info.Errors = append(info.Errors, err.Error())
+	} else {
+		info.Filesystems, _, _ = unstructured.NestedSlice(filesystems, "items")
+	}
+
+	return info, nil
+}
+
+// getGuestSubresource reads and decodes a guest agent subresource of a virtual machine instance.
+func (h *ResourceHandler) getGuestSubresource(ctx context.Context, namespace, name, subresource string) (map[string]interface{}, error) {
+	data, err := h.DoSubresource(ctx, http.MethodGet, vmiSubresourceGVR, namespace, name, subresource, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", subresource, err)
+	}
+
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", subresource, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,161 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+)
+
+// VMVolumeAttachOptions describes a volume to hotplug into a virtual machine.
+type VMVolumeAttachOptions struct {
+	// VolumeName is the name of the disk inside the VM; defaults to the claim name.
+	VolumeName string
+	// ClaimName is the PVC to attach. A name is generated when empty and Size is set.
+	ClaimName string
+	// Size creates a new blank PVC of this size instead of attaching an existing one.
+	Size         string
+	StorageClass string
+}
+
+// AttachVolumeToVM hotplugs an existing or newly created PVC into a virtual machine through the
+// KubeVirt addvolume subresource. Hotplugged disks always use the SCSI bus.
+func (h *ResourceHandler) AttachVolumeToVM(ctx context.Context, namespace, vmName string, opts VMVolumeAttachOptions) (*unstructured.Unstructured, error) {
+	vmGVR := ResourceTypeToGVR[ResourceTypeVM]
+	if _, err := h.GetResource(ctx, vmGVR, namespace, vmName); err != nil {
+		return nil, fmt.Errorf("failed to get virtual machine %s/%s: %w", namespace, vmName, err)
+	}
+
+	claimName := opts.ClaimName
+	createdClaim := false
+	if opts.Size != "" {
+		if claimName == "" {
+			claimName = fmt.Sprintf("%s-hotplug-%s", vmName, utilrand.String(5))
+		}
+		_, err := h.CreateVolume(ctx, VolumeCreateOptions{
+			Name:         claimName,
+			Namespace:    namespace,
+			Size:         opts.Size,
+			StorageClass: opts.StorageClass,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create volume %s/%s: %w", namespace, claimName, err)
+		}
+		createdClaim = true
+	} else {
+		if claimName == "" {
+			return nil, fmt.Errorf("either an existing volume or a size for a new volume is required")
+		}
+		if _, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypePVC], namespace, claimName); err != nil {
+			return nil, fmt.Errorf("failed to get volume %s/%s: %w", namespace, claimName, err)
+		}
+	}
+
+	volumeName := opts.VolumeName
+	if volumeName == "" {
+		volumeName = claimName
+	}
+
+	body := map[string]interface{}{
+		"name": volumeName,
+		"disk": map[string]interface{}{
+			"name": volumeName,
+			"disk": map[string]interface{}{"bus": "scsi"},
+		},
+		"volumeSource": map[string]interface{}{
+			"persistentVolumeClaim": map[string]interface{}{
+				"claimName":    claimName,
+				"hotpluggable": true,
+			},
+		},
+	}
+	if _, err := h.DoSubresource(ctx, http.MethodPut, vmSubresourceGVR, namespace, vmName, "addvolume", body); err != nil {
+		err = fmt.Errorf("failed to attach volume %s: %w", claimName, err)
+		// Only remove the claim created above, never an existing volume
+		if createdClaim {
+			if deleteErr := h.DeleteResource(ctx, ResourceTypeToGVR[ResourceTypePVC], namespace, claimName); deleteErr != nil {
+				return nil, fmt.Errorf("%w (failed to delete volume %s/%s: %v)", err, namespace, claimName, deleteErr)
+			}
+		}
+		return nil, err
+	}
+
+	return h.GetResource(ctx, vmGVR, namespace, vmName)
+}
+
+// DetachVolumeFromVM removes a hotplugged volume from a virtual machine through the KubeVirt
+// removevolume subresource. The underlying PVC is kept.
+func (h *ResourceHandler) DetachVolumeFromVM(ctx context.Context, namespace, vmName, volumeName string) (*unstructured.Unstructured, error) {
+	vmGVR := ResourceTypeToGVR[ResourceTypeVM]
+	vm, err := h.GetResource(ctx, vmGVR, namespace, vmName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get virtual machine %s/%s: %w", namespace, vmName, err)
+	}
+
+	found := false
+	volumes, _, _ := unstructured.NestedSlice(vm.Object, "spec", "template", "spec", "volumes")
+	for _, volumeObj := range volumes {
+		volume, ok := volumeObj.(map[string]interface{})
+		if !ok || getNestedString(volume, "name") != volumeName {
+			continue
+		}
+		if !isHotplugVolume(volume) {
+			return nil, fmt.Errorf("volume %s is not hotplugged and cannot be detached from a running VM", volumeName)
+		}
+		found = true
+	}
+	if !found {
+		return nil, fmt.Errorf("volume %s not found on virtual machine %s/%s", volumeName, namespace, vmName)
+	}
+
+	body := map[string]interface{}{"name": volumeName}
+	if _, err := h.DoSubresource(ctx, http.MethodPut, vmSubresourceGVR, namespace, vmName, "removevolume", body); err != nil {
+		return nil, fmt.Errorf("failed to detach volume %s: %w", volumeName, err)
+	}
+
+	return h.GetResource(ctx, vmGVR, namespace, vmName)
+}
+
+// isHotplugVolume reports whether a VM volume was hotplugged rather than defined with the VM.
+func isHotplugVolume(volume map[string]interface{}) bool {
+	return getNestedBool(volume, "persistentVolumeClaim", "hotpluggable") ||
+		getNestedBool(volume, "dataVolume", "hotpluggable")
+}
+
+// removeHotplugVolumes drops hotplugged volumes and their disks from a VM spec, leaving the volumes defined with the VM.
+func removeHotplugVolumes(vm *unstructured.Unstructured) error {
+	volumesPath := []string{"spec", "template", "spec", "volumes"}
+	disksPath := []string{"spec", "template", "spec", "domain", "devices", "disks"}
+	volumes, _, _ := unstructured.NestedSlice(vm.Object, volumesPath...)
+	disks, _, _ := unstructured.NestedSlice(vm.Object, disksPath...)
+
+	hotplugged := map[string]bool{}
+	var keptVolumes []interface{}
+	for _, volumeObj := range volumes {
+		volume, ok := volumeObj.(map[string]interface{})
+		if ok && isHotplugVolume(volume) {
+			hotplugged[getNestedString(volume, "name")] = true
+			continue
+		}
+		keptVolumes = append(keptVolumes, volumeObj)
+	}
+	if len(hotplugged) == 0 {
+		return nil
+	}
+
+	var keptDisks []interface{}
+	for _, diskObj := range disks {
+		disk, ok := diskObj.(map[string]interface{})
+		if ok && hotplugged[getNestedString(disk, "name")] {
+			continue
+		}
+		keptDisks = append(keptDisks, diskObj)
+	}
+
+	if err := unstructured.SetNestedSlice(vm.Object, keptVolumes, volumesPath...); err != nil {
+		return err
+	}
+	return unstructured.SetNestedSlice(vm.Object, keptDisks, disksPath...)
+}
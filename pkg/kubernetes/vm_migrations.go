@@ -0,0 +1,154 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// annotationMigrationTarget pins the migration target pod to a node through Harvester's pod mutator.
+const annotationMigrationTarget = "harvesterhci.io/migrationTargetNodeName"
+
+// MigrateVirtualMachine starts a live migration of a running VM, optionally to a specific node.
+func (h *ResourceHandler) MigrateVirtualMachine(ctx context.Context, namespace, name, targetNode string) (*unstructured.Unstructured, error) {
+	vmiGVR := ResourceTypeToGVR[ResourceTypeVMI]
+	vmi, err := h.GetResource(ctx, vmiGVR, namespace, name)
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("virtual machine %s/%s is not running", namespace, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get virtual machine instance: %w", err)
+	}
+
+	if phase := getNestedString(vmi.Object, "status", "phase"); phase != "Running" {
+		return nil, fmt.Errorf("virtual machine %s/%s is in phase %s, only running VMs can be migrated", namespace, name, phase)
+	}
+	if !hasTrueCondition(vmi, "LiveMigratable") {
+		return nil, fmt.Errorf("virtual machine %s/%s is not live migratable", namespace, name)
+	}
+
+	active, err := h.findActiveMigration(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, fmt.Errorf("virtual machine %s/%s is already being migrated by %s", namespace, name, active.GetName())
+	}
+
+	if targetNode != "" {
+		if targetNode == getNestedString(vmi.Object, "status", "nodeName") {
+			return nil, fmt.Errorf("virtual machine %s/%s is already running on node %s", namespace, name, targetNode)
+		}
+
+		node, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeNode], "", targetNode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get target node %s: %w", targetNode, err)
+		}
+		if getNestedBool(node.Object, "spec", "unschedulable") {
+			return nil, fmt.Errorf("target node %s is unschedulable", targetNode)
+		}
+	}
+
+	// A target node left over from an earlier migration would pin this one to the same node
+	annotations := vmi.GetAnnotations()
+	if annotations[annotationMigrationTarget] != targetNode {
+		if targetNode == "" {
+			delete(annotations, annotationMigrationTarget)
+		} else {
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[annotationMigrationTarget] = targetNode
+		}
+		vmi.SetAnnotations(annotations)
+		if _, err := h.UpdateResource(ctx, vmiGVR, namespace, vmi); err != nil {
+			return nil, fmt.Errorf("failed to update migration target node: %w", err)
+		}
+	}
+
+	migration := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "kubevirt.io/v1",
+			"kind":       "VirtualMachineInstanceMigration",
+			"metadata": map[string]interface{}{
+				"generateName": name + "-",
+				"namespace":    namespace,
+			},
+			"spec": map[string]interface{}{
+				"vmiName": name,
+			},
+		},
+	}
+
+	return h.CreateResource(ctx, ResourceTypeToGVR[ResourceTypeMigration], namespace, migration)
+}
+
+// ListVMMigrations lists VM migrations, optionally restricted to a single VM. Migrations are enriched
+// with the migration state reported on the VM instance when the migration itself does not carry it.
+func (h *ResourceHandler) ListVMMigrations(ctx context.Context, namespace, vmName string) (*unstructured.UnstructuredList, error) {
+	list, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeMigrations], namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := &unstructured.UnstructuredList{Object: list.Object}
+	for _, item := range list.Items {
+		vmiName := getNestedString(item.Object, "spec", "vmiName")
+		if vmName != "" && vmiName != vmName {
+			continue
+		}
+
+		if _, found, _ := unstructured.NestedMap(item.Object, "status", "migrationState"); !found {
+			vmi, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeVMI], item.GetNamespace(), vmiName)
+			if err == nil && getNestedString(vmi.Object, "status", "migrationState", "migrationUid") == string(item.GetUID()) {
+				state := getNestedMap(vmi.Object, "status", "migrationState")
+				_ = unstructured.SetNestedMap(item.Object, state, "status", "migrationState")
+			}
+		}
+
+		filtered.Items = append(filtered.Items, item)
+	}
+
+	return filtered, nil
+}
+
+// CancelVMMigration aborts the in-progress migration of a VM by deleting its migration object.
+func (h *ResourceHandler) CancelVMMigration(ctx context.Context, namespace, vmName string) (*unstructured.Unstructured, error) {
+	migration, err := h.findActiveMigration(ctx, namespace, vmName)
+	if err != nil {
+		return nil, err
+	}
+	if migration == nil {
+		return nil, fmt.Errorf("virtual machine %s/%s has no migration in progress", namespace, vmName)
+	}
+
+	if err := h.DeleteResource(ctx, ResourceTypeToGVR[ResourceTypeMigration], namespace, migration.GetName()); err != nil {
+		return nil, fmt.Errorf("failed to delete migration %s: %w", migration.GetName(), err)
+	}
+
+	return migration, nil
+}
+
+// findActiveMigration returns the migration of a VM that has not yet succeeded or failed, if any.
+func (h *ResourceHandler) findActiveMigration(ctx context.Context, namespace, vmName string) (*unstructured.Unstructured, error) {
+	list, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeMigrations], namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	for i := range list.Items {
+		migration := &list.Items[i]
+		if getNestedString(migration.Object, "spec", "vmiName") != vmName {
+			continue
+		}
+
+		phase := getNestedString(migration.Object, "status", "phase")
+		if phase != "Succeeded" && phase != "Failed" {
+			return migration, nil
+		}
+	}
+
+	return nil, nil
+}
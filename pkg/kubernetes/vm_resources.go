@@ -0,0 +1,178 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// vmObservedGenerationTimeout bounds how long to wait for KubeVirt to observe a VM spec change.
+const vmObservedGenerationTimeout = 15 * time.Second
+
+// VMResourceOptions describes CPU and memory changes to a virtual machine. Zero values are left unchanged.
+type VMResourceOptions struct {
+	Cores   int64
+	Sockets int64
+	Threads int64
+	Memory  string
+}
+
+// VMResourceUpdateResult describes a virtual machine after its resources were updated.
+type VMResourceUpdateResult struct {
+	VM *unstructured.Unstructured
+	// RestartRequired is true when KubeVirt could not apply the change to the running instance.
+	RestartRequired bool
+}
+
+// UpdateVMResources changes the CPU topology and memory of a virtual machine, retrying on resourceVersion conflicts.
+// Hot-pluggable resources (sockets within maxSockets, memory within maxGuest) are applied to the running instance.
+func (h *ResourceHandler) UpdateVMResources(ctx context.Context, namespace, name string, opts VMResourceOptions) (*VMResourceUpdateResult, error) {
+	var memory resource.Quantity
+	if opts.Memory != "" {
+		var err error
+		memory, err = resource.ParseQuantity(opts.Memory)
+		if err != nil {
+			return nil, fmt.Errorf("invalid memory %q: %w", opts.Memory, err)
+		}
+	}
+	if opts.Cores < 0 || opts.Sockets < 0 || opts.Threads < 0 {
+		return nil, fmt.Errorf("CPU cores, sockets and threads must not be negative")
+	}
+	if opts.Cores == 0 && opts.Sockets == 0 && opts.Threads == 0 && opts.Memory == "" {
+		return nil, fmt.Errorf("no resource changes were requested")
+	}
+
+	gvr := ResourceTypeToGVR[ResourceTypeVM]
+	var updated *unstructured.Unstructured
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		vm, err := h.GetResource(ctx, gvr, namespace, name)
+		if err != nil {
+			return err
+		}
+
+		if err := applyVMCPU(vm, opts); err != nil {
+			return err
+		}
+		if opts.Memory != "" {
+			if err := applyVMMemory(vm, memory); err != nil {
+				return err
+			}
+		}
+
+		updated, err = h.UpdateResource(ctx, gvr, namespace, vm)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update virtual machine %s/%s: %w", namespace, name, err)
+	}
+
+	// Wait for KubeVirt to observe the new spec so the RestartRequired condition is current
+	generation := updated.GetGeneration()
+	_ = wait.PollUntilContextTimeout(ctx, time.Second, vmObservedGenerationTimeout, true, func(ctx context.Context) (bool, error) {
+		vm, err := h.GetResource(ctx, gvr, namespace, name)
+		if err != nil {
+			return false, nil
+		}
+		updated = vm
+		return getNestedInt64(vm.Object, "status", "observedGeneration") >= generation, nil
+	})
+
+	return &VMResourceUpdateResult{
+		VM:              updated,
+		RestartRequired: hasTrueCondition(updated, "RestartRequired"),
+	}, nil
+}
+
+// applyVMCPU sets the CPU topology on the VM template and keeps the CPU limit and request consistent with it.
+func applyVMCPU(vm *unstructured.Unstructured, opts VMResourceOptions) error {
+	if opts.Cores == 0 && opts.Sockets == 0 && opts.Threads == 0 {
+		return nil
+	}
+
+	cpuPath := []string{"spec", "template", "spec", "domain", "cpu"}
+	topology := map[string]int64{"cores": opts.Cores, "sockets": opts.Sockets, "threads": opts.Threads}
+	for field, value := range topology {
+		if value == 0 {
+			value = getNestedInt64(vm.Object, append(cpuPath, field)...)
+			if value == 0 {
+				value = 1
+			}
+		}
+		topology[field] = value
+		if err := unstructured.SetNestedField(vm.Object, value, append(cpuPath, field)...); err != nil {
+			return err
+		}
+	}
+
+	if maxSockets := getNestedInt64(vm.Object, append(cpuPath, "maxSockets")...); maxSockets > 0 && topology["sockets"] > maxSockets {
+		return fmt.Errorf("CPU sockets %d exceed the maximum of %d", topology["sockets"], maxSockets)
+	}
+
+	vcpus := resource.NewQuantity(topology["cores"]*topology["sockets"]*topology["threads"], resource.DecimalSI)
+	resourcesPath := []string{"spec", "template", "spec", "domain", "resources"}
+	if limit := getNestedString(vm.Object, append(resourcesPath, "limits", "cpu")...); limit != "" {
+		if err := unstructured.SetNestedField(vm.Object, vcpus.String(), append(resourcesPath, "limits", "cpu")...); err != nil {
+			return err
+		}
+	}
+	if request := getNestedString(vm.Object, append(resourcesPath, "requests", "cpu")...); request != "" {
+		if current, err := resource.ParseQuantity(request); err == nil && current.Cmp(*vcpus) > 0 {
+			if err := unstructured.SetNestedField(vm.Object, vcpus.String(), append(resourcesPath, "requests", "cpu")...); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyVMMemory sets the memory of the VM template. With memory hotplug (maxGuest) only the guest memory changes;
+// otherwise the limit changes and the guest memory keeps the same reserved overhead below it.
+func applyVMMemory(vm *unstructured.Unstructured, memory resource.Quantity) error {
+	domainPath := []string{"spec", "template", "spec", "domain"}
+
+	if maxGuest := getNestedString(vm.Object, append(domainPath, "memory", "maxGuest")...); maxGuest != "" {
+		if max, err := resource.ParseQuantity(maxGuest); err == nil && memory.Cmp(max) > 0 {
+			return fmt.Errorf("memory %s exceeds the maximum of %s", memory.String(), maxGuest)
+		}
+		return unstructured.SetNestedField(vm.Object, memory.String(), append(domainPath, "memory", "guest")...)
+	}
+
+	limitPath := append(domainPath, "resources", "limits", "memory")
+	guestPath := append(domainPath, "memory", "guest")
+	oldLimit, limitErr := resource.ParseQuantity(getNestedString(vm.Object, limitPath...))
+	oldGuest, guestErr := resource.ParseQuantity(getNestedString(vm.Object, guestPath...))
+
+	if err := unstructured.SetNestedField(vm.Object, memory.String(), limitPath...); err != nil {
+		return err
+	}
+
+	if guestErr == nil {
+		guest := memory.DeepCopy()
+		if limitErr == nil {
+			reserved := oldLimit.DeepCopy()
+			reserved.Sub(oldGuest)
+			guest.Sub(reserved)
+		}
+		if guest.Sign() <= 0 {
+			guest = memory.DeepCopy()
+		}
+		if err := unstructured.SetNestedField(vm.Object, guest.String(), guestPath...); err != nil {
+			return err
+		}
+	}
+
+	requestPath := append(domainPath, "resources", "requests", "memory")
+	if request := getNestedString(vm.Object, requestPath...); request != "" {
+		if current, err := resource.ParseQuantity(request); err == nil && current.Cmp(memory) > 0 {
+			return unstructured.SetNestedField(vm.Object, memory.String(), requestPath...)
+		}
+	}
+
+	return nil
+}
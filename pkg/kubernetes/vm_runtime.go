@@ -0,0 +1,142 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// virtLauncherSelector selects the pods that run virtual machine instances.
+const virtLauncherSelector = "kubevirt.io=virt-launcher"
+
+// VMRuntime is a virtual machine together with the runtime state of its instance.
+type VMRuntime struct {
+	VM *unstructured.Unstructured
+	// Instance is the VirtualMachineInstance of the VM, nil when the VM is not running.
+	Instance *unstructured.Unstructured
+	// LauncherPod is the virt-launcher pod running the instance, nil when it could not be found.
+	LauncherPod *unstructured.Unstructured
+}
+
+// GetVirtualMachine retrieves a virtual machine with its instance and launcher pod. The launcher pod is
+// left out when virt-launcher pods cannot be listed.
+func (h *ResourceHandler) GetVirtualMachine(ctx context.Context, namespace, name string) (*VMRuntime, error) {
+	vm, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeVM], namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VMRuntime{VM: vm}
+	vmi, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeVMI], namespace, name)
+	if apierrors.IsNotFound(err) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get virtual machine instance: %w", err)
+	}
+	result.Instance = vmi
+
+	if pods, err := h.ListResourcesWithLabelSelector(ctx, ResourceTypeToGVR[ResourceTypePods], namespace, virtLauncherSelector); err == nil {
+		result.LauncherPod = findLauncherPod(vmi, pods.Items)
+	}
+	return result, nil
+}
+
+// ListVirtualMachines lists virtual machines with their instances and launcher pods. Launcher pods are
+// left out when virt-launcher pods cannot be listed.
+func (h *ResourceHandler) ListVirtualMachines(ctx context.Context, namespace string) ([]VMRuntime, error) {
+	list, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeVMs], namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	vmis, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeVMIs], namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list virtual machine instances: %w", err)
+	}
+	var pods []unstructured.Unstructured
+	if podList, err := h.ListResourcesWithLabelSelector(ctx, ResourceTypeToGVR[ResourceTypePods], namespace, virtLauncherSelector); err == nil {
+		pods = podList.Items
+	}
+
+	vmisByKey := make(map[string]*unstructured.Unstructured, len(vmis.Items))
+	for i := range vmis.Items {
+		vmi := &vmis.Items[i]
+		vmisByKey[vmi.GetNamespace()+"/"+vmi.GetName()] = vmi
+	}
+
+	vms := make([]VMRuntime, 0, len(list.Items))
+	for i := range list.Items {
+		vm := VMRuntime{VM: &list.Items[i]}
+		if vmi, ok := vmisByKey[vm.VM.GetNamespace()+"/"+vm.VM.GetName()]; ok {
+			vm.Instance = vmi
+			vm.LauncherPod = findLauncherPod(vmi, pods)
+		}
+		vms = append(vms, vm)
+	}
+
+	return vms, nil
+}
+
+// findLauncherPod returns the launcher pod of an instance. When several launcher pods exist, e.g. during
+// a migration, the one on the node currently running the instance wins.
+func findLauncherPod(vmi *unstructured.Unstructured, pods []unstructured.Unstructured) *unstructured.Unstructured {
+	nodeName := getNestedString(vmi.Object, "status", "nodeName")
+	var launcher *unstructured.Unstructured
+	for i := range pods {
+		pod := &pods[i]
+		if pod.GetNamespace() != vmi.GetNamespace() || pod.GetLabels()["kubevirt.io/created-by"] != string(vmi.GetUID()) {
+			continue
+		}
+		if launcher == nil || getNestedString(pod.Object, "spec", "nodeName") == nodeName {
+			launcher = pod
+		}
+	}
+	return launcher
+}
+
+// vmStatus returns the status of a virtual machine as shown by KubeVirt, e.g. Running, Stopped, Starting
+// or ErrorUnschedulable, falling back to the phase of its instance, if any, and the ready and created flags.
+func vmStatus(vm, vmi *unstructured.Unstructured) string {
+	if status := getNestedString(vm.Object, "status", "printableStatus"); status != "" {
+		return status
+	}
+
+	if vmi != nil {
+		if phase := getNestedString(vmi.Object, "status", "phase"); phase != "" {
+			return phase
+		}
+	}
+
+	if getNestedBool(vm.Object, "status", "ready") {
+		return "Running"
+	}
+	if getNestedBool(vm.Object, "status", "created") {
+		return "Created"
+	}
+	return "Unknown"
+}
+
+// vmIPAddresses returns the IP addresses reported on the interfaces of a VM instance.
+func vmIPAddresses(vmi *unstructured.Unstructured) []string {
+	if vmi == nil {
+		return nil
+	}
+	interfaces, _, _ := unstructured.NestedSlice(vmi.Object, "status", "interfaces")
+
+	var ips []string
+	for _, ifaceObj := range interfaces {
+		iface, ok := ifaceObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if addresses := getNestedStringSlice(iface, "ipAddresses"); len(addresses) > 0 {
+			ips = append(ips, addresses...)
+		} else if ip := getNestedString(iface, "ipAddress"); ip != "" {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
@@ -0,0 +1,494 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/util/retry"
+)
+
+// labelTemplateID links a template version to its template, as set by the Harvester template controller.
+const labelTemplateID = "template.harvesterhci.io/templateID"
+
+// VMFromTemplateOptions describes a virtual machine to be created from a template version.
+// Zero values keep the settings stored in the template version.
+type VMFromTemplateOptions struct {
+	Name      string
+	Namespace string
+	// Template is a VirtualMachineTemplate reference ("namespace/name" or "name").
+	Template string
+	// Version is the template version number; the default version is used when zero.
+	Version  int64
+	CPUCores int64
+	Memory   string
+	UserData string
+	// Start overrides the run strategy of the template when set.
+	Start *bool
+}
+
+// VMTemplateSaveOptions describes a new template version to be saved from an existing virtual machine.
+type VMTemplateSaveOptions struct {
+	Namespace   string
+	VMName      string
+	Template    string
+	Description string
+	// SetDefault makes the new version the default version of the template.
+	SetDefault bool
+}
+
+// ListVMTemplates lists VirtualMachineTemplates.
+func (h *ResourceHandler) ListVMTemplates(ctx context.Context, namespace string) (*unstructured.UnstructuredList, error) {
+	return h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeTemplates], namespace)
+}
+
+// ListVMTemplateVersions lists the versions of a template ordered by version number.
+func (h *ResourceHandler) ListVMTemplateVersions(ctx context.Context, namespace, template string) ([]unstructured.Unstructured, error) {
+	list, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeTemplateVersions], namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list template versions: %w", err)
+	}
+
+	templateID := namespace + "/" + template
+	var versions []unstructured.Unstructured
+	for _, item := range list.Items {
+		if getNestedString(item.Object, "spec", "templateId") == templateID {
+			versions = append(versions, item)
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return getNestedInt64(versions[i].Object, "status", "version") < getNestedInt64(versions[j].Object, "status", "version")
+	})
+	return versions, nil
+}
+
+// GetVMTemplateVersion retrieves a version of a template by number, or its default version when version is zero.
+func (h *ResourceHandler) GetVMTemplateVersion(ctx context.Context, namespace, template string, version int64) (*unstructured.Unstructured, error) {
+	tmpl, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeTemplate], namespace, template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template %s/%s: %w", namespace, template, err)
+	}
+
+	if version == 0 {
+		defaultVersionID := getNestedString(tmpl.Object, "spec", "defaultVersionId")
+		if defaultVersionID == "" {
+			return nil, fmt.Errorf("template %s/%s has no default version", namespace, template)
+		}
+		versionNamespace, versionName := splitNamespacedName(defaultVersionID, namespace)
+		return h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeTemplateVersion], versionNamespace, versionName)
+	}
+
+	versions, err := h.ListVMTemplateVersions(ctx, namespace, template)
+	if err != nil {
+		return nil, err
+	}
+	for i := range versions {
+		if getNestedInt64(versions[i].Object, "status", "version") == version {
+			return &versions[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("template %s/%s has no version %d", namespace, template, version)
+}
+
+// CreateVMFromTemplate creates a virtual machine from the VM spec stored in a template version, the same way
+// the Harvester UI does: volume claims and MAC addresses are made unique and the overrides are applied on top.
+func (h *ResourceHandler) CreateVMFromTemplate(ctx context.Context, opts VMFromTemplateOptions) (*unstructured.Unstructured, error) {
+	templateNamespace, templateName := splitNamespacedName(opts.Template, opts.Namespace)
+	version, err := h.GetVMTemplateVersion(ctx, templateNamespace, templateName, opts.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	templateVM := getNestedMap(version.Object, "spec", "vm")
+	if len(templateVM) == 0 {
+		return nil, fmt.Errorf("template version %s/%s has no VM spec", version.GetNamespace(), version.GetName())
+	}
+
+	vm := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "kubevirt.io/v1",
+		"kind":       "VirtualMachine",
+		"metadata": map[string]interface{}{
+			"name":        opts.Name,
+			"namespace":   opts.Namespace,
+			"annotations": getNestedMap(templateVM, "metadata", "annotations"),
+			"labels":      getNestedMap(templateVM, "metadata", "labels"),
+		},
+		"spec": getNestedMap(templateVM, "spec"),
+	}}
+	if err := unstructured.SetNestedField(vm.Object, "harvester", "metadata", "labels", labelCreator); err != nil {
+		return nil, err
+	}
+	if err := unstructured.SetNestedField(vm.Object, opts.Name, "spec", "template", "metadata", "labels", labelVMName); err != nil {
+		return nil, err
+	}
+	if err := unstructured.SetNestedField(vm.Object, opts.Name, "spec", "template", "spec", "hostname"); err != nil {
+		return nil, err
+	}
+
+	if err := renameVMClaimTemplates(vm); err != nil {
+		return nil, err
+	}
+	if err := removeVMMacAddresses(vm); err != nil {
+		return nil, err
+	}
+	if err := h.inlineCloudInitSecrets(ctx, vm, version.GetNamespace()); err != nil {
+		return nil, err
+	}
+
+	if err := applyTemplateOverrides(vm, opts); err != nil {
+		return nil, err
+	}
+
+	return h.CreateResource(ctx, ResourceTypeToGVR[ResourceTypeVM], opts.Namespace, vm)
+}
+
+// SaveVMAsTemplate stores the spec of an existing virtual machine as a new version of a template, creating
+// the template when it does not exist yet. Disk contents are not copied: disks provisioned from images are
+// provisioned from the same image again and other disks are recreated blank with the same size.
+func (h *ResourceHandler) SaveVMAsTemplate(ctx context.Context, opts VMTemplateSaveOptions) (*unstructured.Unstructured, error) {
+	vm, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeVM], opts.Namespace, opts.VMName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get virtual machine %s/%s: %w", opts.Namespace, opts.VMName, err)
+	}
+
+	templateVM, err := h.buildTemplateVM(ctx, vm)
+	if err != nil {
+		return nil, err
+	}
+
+	templateGVR := ResourceTypeToGVR[ResourceTypeTemplate]
+	createdTemplate := false
+	_, err = h.GetResource(ctx, templateGVR, opts.Namespace, opts.Template)
+	if apierrors.IsNotFound(err) {
+		createdTemplate = true
+		_, err = h.CreateResource(ctx, templateGVR, opts.Namespace, &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "harvesterhci.io/v1beta1",
+			"kind":       "VirtualMachineTemplate",
+			"metadata": map[string]interface{}{
+				"name":      opts.Template,
+				"namespace": opts.Namespace,
+			},
+			"spec": map[string]interface{}{
+				"description": opts.Description,
+			},
+		}})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template %s/%s: %w", opts.Namespace, opts.Template, err)
+	}
+
+	var keyPairIDs []interface{}
+	if sshNames := vm.GetAnnotations()[annotationSSHNames]; sshNames != "" {
+		var names []string
+		if err := json.Unmarshal([]byte(sshNames), &names); err == nil {
+			for _, name := range names {
+				keyPairIDs = append(keyPairIDs, name)
+			}
+		}
+	}
+
+	spec := map[string]interface{}{
+		"templateId":  opts.Namespace + "/" + opts.Template,
+		"description": opts.Description,
+		"vm":          templateVM,
+	}
+	if imageID := vm.GetAnnotations()[annotationImageID]; imageID != "" {
+		spec["imageId"] = imageID
+	}
+	if len(keyPairIDs) > 0 {
+		spec["keyPairIds"] = keyPairIDs
+	}
+
+	version, err := h.CreateResource(ctx, ResourceTypeToGVR[ResourceTypeTemplateVersion], opts.Namespace, &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "harvesterhci.io/v1beta1",
+		"kind":       "VirtualMachineTemplateVersion",
+		"metadata": map[string]interface{}{
+			"generateName": opts.Template + "-",
+			"namespace":    opts.Namespace,
+			"labels": map[string]interface{}{
+				labelTemplateID: opts.Template,
+			},
+		},
+		"spec": spec,
+	}})
+	if err != nil {
+		err = fmt.Errorf("failed to create template version: %w", err)
+		if createdTemplate {
+			if deleteErr := h.DeleteResource(ctx, templateGVR, opts.Namespace, opts.Template); deleteErr != nil {
+				return nil, fmt.Errorf("%w (failed to delete template %s/%s: %v)", err, opts.Namespace, opts.Template, deleteErr)
+			}
+		}
+		return nil, err
+	}
+
+	// The template controller updates the template once a version exists, so work on a fresh copy
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		tmpl, err := h.GetResource(ctx, templateGVR, opts.Namespace, opts.Template)
+		if err != nil {
+			return err
+		}
+		if !opts.SetDefault && getNestedString(tmpl.Object, "spec", "defaultVersionId") != "" {
+			return nil
+		}
+		if err := unstructured.SetNestedField(tmpl.Object, version.GetNamespace()+"/"+version.GetName(), "spec", "defaultVersionId"); err != nil {
+			return err
+		}
+		_, err = h.UpdateResource(ctx, templateGVR, opts.Namespace, tmpl)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set default version of template %s/%s: %w", opts.Namespace, opts.Template, err)
+	}
+
+	return version, nil
+}
+
+// buildTemplateVM converts a virtual machine into the VM spec stored in a template version. Hotplugged volumes
+// are dropped and every remaining volume claim is described by a volume claim template.
+func (h *ResourceHandler) buildTemplateVM(ctx context.Context, vm *unstructured.Unstructured) (map[string]interface{}, error) {
+	spec := getNestedMap(vm.Object, "spec")
+	delete(spec, "dataVolumeTemplates")
+	copied := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+
+	if err := removeHotplugVolumes(copied); err != nil {
+		return nil, err
+	}
+
+	volumes, _, _ := unstructured.NestedSlice(copied.Object, "spec", "template", "spec", "volumes")
+	var claimTemplates []map[string]interface{}
+	for _, volumeObj := range volumes {
+		volume, ok := volumeObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		claimName := getNestedString(volume, "persistentVolumeClaim", "claimName")
+		if claimName == "" {
+			continue
+		}
+		pvc, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypePVC], vm.GetNamespace(), claimName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get volume claim %s/%s: %w", vm.GetNamespace(), claimName, err)
+		}
+		claimTemplates = append(claimTemplates, pvcClaimTemplate(pvc))
+	}
+
+	if err := removeVMMacAddresses(copied); err != nil {
+		return nil, err
+	}
+	if err := h.inlineCloudInitSecrets(ctx, copied, vm.GetNamespace()); err != nil {
+		return nil, err
+	}
+
+	claimTemplatesJSON, err := json.Marshal(claimTemplates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode volume claim templates: %w", err)
+	}
+	annotations := map[string]interface{}{
+		annotationVolumeClaimTemplates: string(claimTemplatesJSON),
+	}
+	if sshNames := vm.GetAnnotations()[annotationSSHNames]; sshNames != "" {
+		annotations[annotationSSHNames] = sshNames
+	}
+
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+		"spec": copied.Object["spec"],
+	}, nil
+}
+
+// pvcClaimTemplate describes a PersistentVolumeClaim as an entry of the volume claim templates annotation.
+func pvcClaimTemplate(pvc *unstructured.Unstructured) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"name": pvc.GetName(),
+	}
+	if imageID := pvc.GetAnnotations()[annotationImageID]; imageID != "" {
+		metadata["annotations"] = map[string]interface{}{annotationImageID: imageID}
+	}
+
+	spec := map[string]interface{}{
+		"accessModes": getNestedStringSlice(pvc.Object, "spec", "accessModes"),
+		"resources": map[string]interface{}{
+			"requests": map[string]interface{}{
+				"storage": getNestedString(pvc.Object, "spec", "resources", "requests", "storage"),
+			},
+		},
+		"volumeMode": getNestedString(pvc.Object, "spec", "volumeMode"),
+	}
+	if storageClass := getNestedString(pvc.Object, "spec", "storageClassName"); storageClass != "" {
+		spec["storageClassName"] = storageClass
+	}
+
+	return map[string]interface{}{
+		"metadata": metadata,
+		"spec":     spec,
+	}
+}
+
+// renameVMClaimTemplates gives every volume claim template of a VM a unique name derived from the VM name
+// and points the matching volumes at the renamed claims.
+func renameVMClaimTemplates(vm *unstructured.Unstructured) error {
+	annotations := vm.GetAnnotations()
+	if annotations[annotationVolumeClaimTemplates] == "" {
+		return nil
+	}
+
+	var claimTemplates []map[string]interface{}
+	if err := json.Unmarshal([]byte(annotations[annotationVolumeClaimTemplates]), &claimTemplates); err != nil {
+		return fmt.Errorf("failed to parse volume claim templates: %w", err)
+	}
+
+	volumes, _, _ := unstructured.NestedSlice(vm.Object, "spec", "template", "spec", "volumes")
+	for _, claimTemplate := range claimTemplates {
+		oldName := getNestedString(claimTemplate, "metadata", "name")
+		for _, volumeObj := range volumes {
+			volume, ok := volumeObj.(map[string]interface{})
+			if !ok || getNestedString(volume, "persistentVolumeClaim", "claimName") != oldName {
+				continue
+			}
+
+			newName := fmt.Sprintf("%s-%s-%s", vm.GetName(), getNestedString(volume, "name"), utilrand.String(5))
+			if err := unstructured.SetNestedField(volume, newName, "persistentVolumeClaim", "claimName"); err != nil {
+				return err
+			}
+			if err := unstructured.SetNestedField(claimTemplate, newName, "metadata", "name"); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	claimTemplatesJSON, err := json.Marshal(claimTemplates)
+	if err != nil {
+		return fmt.Errorf("failed to encode volume claim templates: %w", err)
+	}
+	annotations[annotationVolumeClaimTemplates] = string(claimTemplatesJSON)
+	vm.SetAnnotations(annotations)
+
+	return unstructured.SetNestedSlice(vm.Object, volumes, "spec", "template", "spec", "volumes")
+}
+
+// removeVMMacAddresses clears fixed MAC addresses from the interfaces of a VM so new ones are assigned.
+func removeVMMacAddresses(vm *unstructured.Unstructured) error {
+	interfacesPath := []string{"spec", "template", "spec", "domain", "devices", "interfaces"}
+	interfaces, found, _ := unstructured.NestedSlice(vm.Object, interfacesPath...)
+	if !found {
+		return nil
+	}
+
+	for _, ifaceObj := range interfaces {
+		if iface, ok := ifaceObj.(map[string]interface{}); ok {
+			delete(iface, "macAddress")
+		}
+	}
+	return unstructured.SetNestedSlice(vm.Object, interfaces, interfacesPath...)
+}
+
+// inlineCloudInitSecrets replaces cloud-init secret references of a VM with the secret contents, so that
+// the VM does not depend on a secret owned by another VM or template.
+func (h *ResourceHandler) inlineCloudInitSecrets(ctx context.Context, vm *unstructured.Unstructured, secretNamespace string) error {
+	volumesPath := []string{"spec", "template", "spec", "volumes"}
+	volumes, _, _ := unstructured.NestedSlice(vm.Object, volumesPath...)
+
+	for _, volumeObj := range volumes {
+		volume, ok := volumeObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		cloudInit, ok := volume["cloudInitNoCloud"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		refs := map[string]string{"secretRef": "userdata", "networkDataSecretRef": "networkdata"}
+		for refField, dataKey := range refs {
+			secretName := getNestedString(cloudInit, refField, "name")
+			if secretName == "" {
+				continue
+			}
+
+			secret, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeSecret], secretNamespace, secretName)
+			if err != nil {
+				return fmt.Errorf("failed to get cloud-init secret %s/%s: %w", secretNamespace, secretName, err)
+			}
+			data, err := base64.StdEncoding.DecodeString(getNestedString(secret.Object, "data", dataKey))
+			if err != nil {
+				return fmt.Errorf("failed to decode cloud-init secret %s/%s: %w", secretNamespace, secretName, err)
+			}
+
+			delete(cloudInit, refField)
+			if refField == "secretRef" {
+				cloudInit["userData"] = string(data)
+			} else if len(data) > 0 {
+				cloudInit["networkData"] = string(data)
+			}
+		}
+	}
+
+	return unstructured.SetNestedSlice(vm.Object, volumes, volumesPath...)
+}
+
+// applyTemplateOverrides applies the CPU, memory, cloud-init and run strategy overrides to a VM built from a template.
+func applyTemplateOverrides(vm *unstructured.Unstructured, opts VMFromTemplateOptions) error {
+	if opts.CPUCores < 0 {
+		return fmt.Errorf("CPU cores must not be negative")
+	}
+	if err := applyVMCPU(vm, VMResourceOptions{Cores: opts.CPUCores}); err != nil {
+		return err
+	}
+
+	if opts.Memory != "" {
+		memory, err := resource.ParseQuantity(opts.Memory)
+		if err != nil {
+			return fmt.Errorf("invalid memory %q: %w", opts.Memory, err)
+		}
+		if err := applyVMMemory(vm, memory); err != nil {
+			return err
+		}
+	}
+
+	if opts.UserData != "" {
+		volumesPath := []string{"spec", "template", "spec", "volumes"}
+		volumes, _, _ := unstructured.NestedSlice(vm.Object, volumesPath...)
+		applied := false
+		for _, volumeObj := range volumes {
+			volume, ok := volumeObj.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if cloudInit, ok := volume["cloudInitNoCloud"].(map[string]interface{}); ok {
+				delete(cloudInit, "userDataBase64")
+				cloudInit["userData"] = opts.UserData
+				applied = true
+			}
+		}
+		if !applied {
+			return fmt.Errorf("the template has no cloud-init disk to apply user data to")
+		}
+		if err := unstructured.SetNestedSlice(vm.Object, volumes, volumesPath...); err != nil {
+			return err
+		}
+	}
+
+	if opts.Start != nil {
+		runStrategy := "Halted"
+		if *opts.Start {
+			runStrategy = "RerunOnFailure"
+		}
+		unstructured.RemoveNestedField(vm.Object, "spec", "running")
+		if err := unstructured.SetNestedField(vm.Object, runStrategy, "spec", "runStrategy"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
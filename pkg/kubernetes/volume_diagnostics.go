@@ -0,0 +1,238 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// labelLonghornVolume is the label Longhorn puts on the replicas and engines of a volume.
+const labelLonghornVolume = "longhornvolume"
+
+// Longhorn volume robustness values
+const (
+	longhornRobustnessHealthy  = "healthy"
+	longhornRobustnessDegraded = "degraded"
+	longhornRobustnessFaulted  = "faulted"
+)
+
+// LonghornReplicaStatus describes a replica of a Longhorn volume as seen by the volume's engine.
+type LonghornReplicaStatus struct {
+	Name     string
+	Node     string
+	DiskPath string
+	// State is the current state of the replica process, e.g. running, stopped or error.
+	State string
+	// Mode is the mode the engine reports for the replica: RW (healthy), WO (rebuilding) or ERR.
+	Mode     string
+	Healthy  bool
+	FailedAt string
+	// Rebuilding is set while the replica is being rebuilt from a healthy one, with Progress in percent.
+	Rebuilding   bool
+	Progress     int64
+	RebuildError string
+}
+
+// LonghornEngineStatus describes an engine of a Longhorn volume.
+type LonghornEngineStatus struct {
+	Name  string
+	Node  string
+	State string
+}
+
+// VolumeDiagnosis gathers the Longhorn state behind a volume claim.
+type VolumeDiagnosis struct {
+	// PVC is the diagnosed volume claim.
+	PVC *unstructured.Unstructured
+	// Volume is the Longhorn volume backing the claim, nil if there is none.
+	Volume   *unstructured.Unstructured
+	Engines  []LonghornEngineStatus
+	Replicas []LonghornReplicaStatus
+	// SchedulingFailure is the reason Longhorn reports for replicas it cannot place on any node and disk.
+	SchedulingFailure string
+	// Summary explains the findings in plain language.
+	Summary []string
+}
+
+// DiagnoseVolume collects the Longhorn volume, engines and replicas backing a volume claim and
+// summarizes its health, rebuild progress and scheduling problems.
+func (h *ResourceHandler) DiagnoseVolume(ctx context.Context, namespace, name string) (*VolumeDiagnosis, error) {
+	pvc, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypePVC], namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volume %s/%s: %w", namespace, name, err)
+	}
+
+	diagnosis := &VolumeDiagnosis{PVC: pvc}
+	volumeName := getNestedString(pvc.Object, "spec", "volumeName")
+	if volumeName == "" {
+		diagnosis.Summary = append(diagnosis.Summary, fmt.Sprintf("The volume is %s and not bound to a persistent volume yet, so there is no Longhorn volume to diagnose.", strings.ToLower(getNestedString(pvc.Object, "status", "phase"))))
+		return diagnosis, nil
+	}
+
+	diagnosis.Volume, err = h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeLonghornVolume], longhornNamespace, volumeName)
+	if apierrors.IsNotFound(err) {
+		diagnosis.Summary = append(diagnosis.Summary, fmt.Sprintf("No Longhorn volume named %s exists; the volume is not provisioned by Longhorn.", volumeName))
+		return diagnosis, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get Longhorn volume %s: %w", volumeName, err)
+	}
+
+	selector := fmt.Sprintf("%s=%s", labelLonghornVolume, volumeName)
+	engines, err := h.ListResourcesWithLabelSelector(ctx, ResourceTypeToGVR[ResourceTypeLonghornEngines], longhornNamespace, selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list engines of Longhorn volume %s: %w", volumeName, err)
+	}
+	replicas, err := h.ListResourcesWithLabelSelector(ctx, ResourceTypeToGVR[ResourceTypeLonghornReplicas], longhornNamespace, selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicas of Longhorn volume %s: %w", volumeName, err)
+	}
+
+	// Replica modes and rebuild progress are reported by the engine, keyed by replica name and address
+	modes := map[string]string{}
+	addresses := map[string]string{}
+	rebuilds := map[string]map[string]interface{}{}
+	for _, engine := range engines.Items {
+		diagnosis.Engines = append(diagnosis.Engines, LonghornEngineStatus{
+			Name:  engine.GetName(),
+			Node:  getNestedString(engine.Object, "spec", "nodeID"),
+			State: getNestedString(engine.Object, "status", "currentState"),
+		})
+		for replica, mode := range getNestedMap(engine.Object, "status", "replicaModeMap") {
+			modes[replica], _ = mode.(string)
+		}
+		for replica, address := range getNestedMap(engine.Object, "status", "currentReplicaAddressMap") {
+			addressString, _ := address.(string)
+			addresses[replica] = strings.TrimPrefix(addressString, "tcp://")
+		}
+		for address, rebuild := range getNestedMap(engine.Object, "status", "rebuildStatus") {
+			if rebuildMap, ok := rebuild.(map[string]interface{}); ok {
+				rebuilds[strings.TrimPrefix(address, "tcp://")] = rebuildMap
+			}
+		}
+	}
+
+	for _, replica := range replicas.Items {
+		status := LonghornReplicaStatus{
+			Name:     replica.GetName(),
+			Node:     getNestedString(replica.Object, "spec", "nodeID"),
+			DiskPath: getNestedString(replica.Object, "spec", "diskPath"),
+			State:    getNestedString(replica.Object, "status", "currentState"),
+			Mode:     modes[replica.GetName()],
+			FailedAt: getNestedString(replica.Object, "spec", "failedAt"),
+		}
+		if len(diagnosis.Engines) > 0 && len(modes) > 0 {
+			status.Healthy = status.Mode == "RW"
+		} else {
+			// Without a running engine, fall back to the replica's own record of its health
+			status.Healthy = status.FailedAt == "" && getNestedString(replica.Object, "spec", "healthyAt") != ""
+		}
+		if rebuild, ok := rebuilds[addresses[replica.GetName()]]; ok {
+			status.Rebuilding = getNestedBool(rebuild, "isRebuilding")
+			status.Progress = getNestedInt64(rebuild, "progress")
+			status.RebuildError = getNestedString(rebuild, "error")
+		} else if status.Mode == "WO" {
+			status.Rebuilding = true
+		}
+		diagnosis.Replicas = append(diagnosis.Replicas, status)
+	}
+	sort.Slice(diagnosis.Replicas, func(i, j int) bool {
+		return diagnosis.Replicas[i].Name < diagnosis.Replicas[j].Name
+	})
+
+	conditions, _, _ := unstructured.NestedSlice(diagnosis.Volume.Object, "status", "conditions")
+	for _, condObj := range conditions {
+		cond, ok := condObj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if getNestedString(cond, "type") == "Scheduled" && getNestedString(cond, "status") == "False" {
+			diagnosis.SchedulingFailure = getNestedString(cond, "message")
+			if diagnosis.SchedulingFailure == "" {
+				diagnosis.SchedulingFailure = getNestedString(cond, "reason")
+			}
+		}
+	}
+
+	diagnosis.Summary = summarizeVolumeDiagnosis(diagnosis)
+	return diagnosis, nil
+}
+
+// summarizeVolumeDiagnosis explains the state of a Longhorn volume in plain language.
+func summarizeVolumeDiagnosis(diagnosis *VolumeDiagnosis) []string {
+	var summary []string
+	volume := diagnosis.Volume.Object
+	state := getNestedString(volume, "status", "state")
+	robustness := getNestedString(volume, "status", "robustness")
+	desired := getNestedInt64(volume, "spec", "numberOfReplicas")
+
+	var healthy []LonghornReplicaStatus
+	for _, replica := range diagnosis.Replicas {
+		if replica.Healthy {
+			healthy = append(healthy, replica)
+		}
+	}
+	var healthyNodes []string
+	for _, replica := range healthy {
+		healthyNodes = append(healthyNodes, replica.Node)
+	}
+
+	switch {
+	case state == "detached":
+		summary = append(summary, fmt.Sprintf("The volume is detached because no running VM uses it; Longhorn only checks replica health while a volume is attached. %d of %d replicas were healthy when it was last attached.", len(healthy), desired))
+	case robustness == longhornRobustnessHealthy:
+		summary = append(summary, fmt.Sprintf("The volume is healthy: %d of %d replicas are healthy, on nodes %s.", len(healthy), desired, strings.Join(healthyNodes, ", ")))
+	case robustness == longhornRobustnessDegraded:
+		summary = append(summary, fmt.Sprintf("The volume is degraded: only %d of %d replicas are healthy. It keeps serving I/O, but losing another replica could make the data unavailable.", len(healthy), desired))
+	case robustness == longhornRobustnessFaulted:
+		summary = append(summary, "The volume is faulted: no healthy replica is available, so its data cannot be accessed until a replica is salvaged.")
+	default:
+		summary = append(summary, fmt.Sprintf("The volume is %s with robustness %s.", state, robustness))
+	}
+
+	nodeReplicas := map[string][]string{}
+	for _, replica := range diagnosis.Replicas {
+		switch {
+		case replica.Rebuilding && replica.RebuildError != "":
+			summary = append(summary, fmt.Sprintf("Rebuilding replica %s on node %s failed: %s", replica.Name, replica.Node, replica.RebuildError))
+		case replica.Rebuilding:
+			summary = append(summary, fmt.Sprintf("Replica %s on node %s is being rebuilt (%d%% done); the volume returns to healthy once it completes.", replica.Name, replica.Node, replica.Progress))
+		case replica.FailedAt != "":
+			summary = append(summary, fmt.Sprintf("Replica %s on node %s failed at %s; Longhorn replaces failed replicas automatically if a node and disk can take them.", replica.Name, replica.Node, replica.FailedAt))
+		}
+		if replica.Node == "" {
+			continue
+		}
+		if replica.FailedAt == "" {
+			nodeReplicas[replica.Node] = append(nodeReplicas[replica.Node], replica.Name)
+		}
+	}
+
+	if diagnosis.SchedulingFailure != "" {
+		summary = append(summary, fmt.Sprintf("Longhorn cannot schedule all replicas: %s. Check that enough schedulable nodes with free disk space match the storage class's node and disk selectors.", diagnosis.SchedulingFailure))
+	}
+
+	var nodes []string
+	for node := range nodeReplicas {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	for _, node := range nodes {
+		if len(nodeReplicas[node]) > 1 {
+			summary = append(summary, fmt.Sprintf("Replicas %s are all on node %s, so losing that node loses all of them.", strings.Join(nodeReplicas[node], ", "), node))
+		}
+	}
+
+	if state == "attached" {
+		for _, engine := range diagnosis.Engines {
+			if engine.State != "" && engine.State != "running" {
+				summary = append(summary, fmt.Sprintf("Engine %s on node %s is %s although the volume is attached.", engine.Name, engine.Node, engine.State))
+			}
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,284 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// volumeSnapshotPollInterval is how often a VolumeSnapshot is checked while waiting for it to become ready.
+const volumeSnapshotPollInterval = 2 * time.Second
+
+// SettingCSIDriverConfig is the name of the Harvester setting that maps CSI drivers to their snapshot classes.
+const SettingCSIDriverConfig = "csi-driver-config"
+
+// csiDriverConfig is the per-driver entry of the csi-driver-config setting.
+type csiDriverConfig struct {
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName"`
+}
+
+// VolumeSnapshotDetails is a VolumeSnapshot together with the VolumeSnapshotContent bound to it.
+type VolumeSnapshotDetails struct {
+	Snapshot *unstructured.Unstructured
+	// Content is the bound VolumeSnapshotContent, nil when it is not bound yet or could not be read.
+	Content *unstructured.Unstructured
+}
+
+// VolumeSnapshotRestoreOptions describes a new volume claim to be restored from a VolumeSnapshot.
+// Unset fields are taken from the volume the snapshot was taken of.
+type VolumeSnapshotRestoreOptions struct {
+	Namespace    string
+	SnapshotName string
+	Name         string
+	StorageClass string
+	AccessMode   string
+	VolumeMode   string
+}
+
+// CreateVolumeSnapshot creates a VolumeSnapshot of a volume claim using the snapshot class Harvester configures
+// for the CSI driver of the claim, falling back to the default snapshot class. A name is generated when empty.
+func (h *ResourceHandler) CreateVolumeSnapshot(ctx context.Context, namespace, volumeName, snapshotName string) (*unstructured.Unstructured, error) {
+	pvc, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypePVC], namespace, volumeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volume %s/%s: %w", namespace, volumeName, err)
+	}
+
+	snapshotClass, err := h.volumeSnapshotClassFor(ctx, pvc)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshotName == "" {
+		snapshotName = fmt.Sprintf("%s-snapshot-%s", volumeName, time.Now().UTC().Format("20060102150405"))
+	}
+
+	snapshot := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "snapshot.storage.k8s.io/v1",
+			"kind":       "VolumeSnapshot",
+			"metadata": map[string]interface{}{
+				"name":      snapshotName,
+				"namespace": namespace,
+			},
+			"spec": map[string]interface{}{
+				"source": map[string]interface{}{
+					"persistentVolumeClaimName": volumeName,
+				},
+			},
+		},
+	}
+	if snapshotClass != "" {
+		if err := unstructured.SetNestedField(snapshot.Object, snapshotClass, "spec", "volumeSnapshotClassName"); err != nil {
+			return nil, err
+		}
+	}
+
+	snapshot, err = h.CreateResource(ctx, ResourceTypeToGVR[ResourceTypeVolumeSnapshot], namespace, snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot volume %s/%s: %w", namespace, volumeName, err)
+	}
+	return snapshot, nil
+}
+
+// volumeSnapshotClassFor resolves the snapshot class for a volume claim from the csi-driver-config setting,
+// keyed by the CSI driver that provisioned the claim. An empty name selects the default snapshot class.
+func (h *ResourceHandler) volumeSnapshotClassFor(ctx context.Context, pvc *unstructured.Unstructured) (string, error) {
+	provisioner := pvc.GetAnnotations()["volume.kubernetes.io/storage-provisioner"]
+	if provisioner == "" {
+		provisioner = pvc.GetAnnotations()["volume.beta.kubernetes.io/storage-provisioner"]
+	}
+	if provisioner == "" {
+		if storageClassName := getNestedString(pvc.Object, "spec", "storageClassName"); storageClassName != "" {
+			storageClass, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeStorageClass], "", storageClassName)
+			if err != nil {
+				return "", fmt.Errorf("failed to get storage class %s: %w", storageClassName, err)
+			}
+			provisioner = getNestedString(storageClass.Object, "provisioner")
+		}
+	}
+	if provisioner == "" {
+		return "", nil
+	}
+
+	setting, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeSetting], "", SettingCSIDriverConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s setting: %w", SettingCSIDriverConfig, err)
+	}
+	value := getNestedString(setting.Object, "value")
+	if value == "" {
+		value = getNestedString(setting.Object, "default")
+	}
+	if value == "" {
+		return "", nil
+	}
+
+	var drivers map[string]csiDriverConfig
+	if err := json.Unmarshal([]byte(value), &drivers); err != nil {
+		return "", fmt.Errorf("failed to parse %s setting: %w", SettingCSIDriverConfig, err)
+	}
+	return drivers[provisioner].VolumeSnapshotClassName, nil
+}
+
+// WaitForVolumeSnapshot waits until a VolumeSnapshot is ready to use, failing early when the
+// snapshot controller reports an error.
+func (h *ResourceHandler) WaitForVolumeSnapshot(ctx context.Context, namespace, name string, timeout time.Duration) (*unstructured.Unstructured, error) {
+	gvr := ResourceTypeToGVR[ResourceTypeVolumeSnapshot]
+	var snapshot *unstructured.Unstructured
+	err := wait.PollUntilContextTimeout(ctx, volumeSnapshotPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		var err error
+		snapshot, err = h.GetResource(ctx, gvr, namespace, name)
+		if err != nil {
+			return false, err
+		}
+		if message := getNestedString(snapshot.Object, "status", "error", "message"); message != "" {
+			return false, fmt.Errorf("%s", message)
+		}
+		return getNestedBool(snapshot.Object, "status", "readyToUse"), nil
+	})
+	if err != nil {
+		return snapshot, fmt.Errorf("volume snapshot %s/%s did not become ready: %w", namespace, name, err)
+	}
+	return snapshot, nil
+}
+
+// GetVolumeSnapshot retrieves a VolumeSnapshot with its bound VolumeSnapshotContent.
+func (h *ResourceHandler) GetVolumeSnapshot(ctx context.Context, namespace, name string) (*VolumeSnapshotDetails, error) {
+	snapshot, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeVolumeSnapshot], namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &VolumeSnapshotDetails{Snapshot: snapshot}
+	if contentName := getNestedString(snapshot.Object, "status", "boundVolumeSnapshotContentName"); contentName != "" {
+		content, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeVolumeSnapshotContent], "", contentName)
+		if err == nil {
+			details.Content = content
+		}
+	}
+
+	return details, nil
+}
+
+// ListVolumeSnapshots lists VolumeSnapshots, optionally restricted to those of a single volume.
+func (h *ResourceHandler) ListVolumeSnapshots(ctx context.Context, namespace, volumeName string) (*unstructured.UnstructuredList, error) {
+	list, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeVolumeSnapshots], namespace)
+	if err != nil {
+		return nil, err
+	}
+	if volumeName == "" {
+		return list, nil
+	}
+
+	filtered := &unstructured.UnstructuredList{Object: list.Object}
+	for _, item := range list.Items {
+		if getNestedString(item.Object, "spec", "source", "persistentVolumeClaimName") == volumeName {
+			filtered.Items = append(filtered.Items, item)
+		}
+	}
+	return filtered, nil
+}
+
+// RestoreVolumeSnapshot creates a new volume claim populated from a ready VolumeSnapshot.
+// The new volume keeps the storage class, access modes, volume mode and image of the
+// snapshotted volume when it still exists.
+func (h *ResourceHandler) RestoreVolumeSnapshot(ctx context.Context, opts VolumeSnapshotRestoreOptions) (*unstructured.Unstructured, error) {
+	snapshot, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeVolumeSnapshot], opts.Namespace, opts.SnapshotName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volume snapshot %s/%s: %w", opts.Namespace, opts.SnapshotName, err)
+	}
+	if !getNestedBool(snapshot.Object, "status", "readyToUse") {
+		return nil, fmt.Errorf("volume snapshot %s/%s is not ready to use", opts.Namespace, opts.SnapshotName)
+	}
+
+	spec := map[string]interface{}{
+		"dataSource": map[string]interface{}{
+			"apiGroup": "snapshot.storage.k8s.io",
+			"kind":     "VolumeSnapshot",
+			"name":     opts.SnapshotName,
+		},
+	}
+	metadata := map[string]interface{}{
+		"name":      opts.Name,
+		"namespace": opts.Namespace,
+	}
+
+	size := getNestedString(snapshot.Object, "status", "restoreSize")
+	sourceName := getNestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName")
+	if source, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypePVC], opts.Namespace, sourceName); err == nil {
+		if accessModes, found, _ := unstructured.NestedSlice(source.Object, "spec", "accessModes"); found {
+			spec["accessModes"] = accessModes
+		}
+		for _, field := range []string{"storageClassName", "volumeMode"} {
+			if value := getNestedString(source.Object, "spec", field); value != "" {
+				spec[field] = value
+			}
+		}
+		if imageID := source.GetAnnotations()[annotationImageID]; imageID != "" {
+			metadata["annotations"] = map[string]interface{}{annotationImageID: imageID}
+		}
+		// The snapshot may not report a restore size, and the claim must not be smaller than the source
+		sourceSize := getNestedString(source.Object, "spec", "resources", "requests", "storage")
+		if sourceQuantity, err := resource.ParseQuantity(sourceSize); err == nil {
+			if restoreQuantity, err := resource.ParseQuantity(size); err != nil || sourceQuantity.Cmp(restoreQuantity) > 0 {
+				size = sourceSize
+			}
+		}
+	}
+	if size == "" {
+		return nil, fmt.Errorf("volume snapshot %s/%s has no restore size and its source volume %s no longer exists", opts.Namespace, opts.SnapshotName, sourceName)
+	}
+	spec["resources"] = map[string]interface{}{
+		"requests": map[string]interface{}{"storage": size},
+	}
+
+	if opts.StorageClass != "" {
+		spec["storageClassName"] = opts.StorageClass
+	}
+	if opts.AccessMode != "" {
+		spec["accessModes"] = []interface{}{opts.AccessMode}
+	} else if _, ok := spec["accessModes"]; !ok {
+		spec["accessModes"] = []interface{}{defaultVolumeAccessMode}
+	}
+	if opts.VolumeMode != "" {
+		spec["volumeMode"] = opts.VolumeMode
+	} else if _, ok := spec["volumeMode"]; !ok {
+		spec["volumeMode"] = defaultVolumeMode
+	}
+
+	pvc := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "PersistentVolumeClaim",
+			"metadata":   metadata,
+			"spec":       spec,
+		},
+	}
+
+	return h.CreateResource(ctx, ResourceTypeToGVR[ResourceTypePVC], opts.Namespace, pvc)
+}
+
+// DeleteVolumeSnapshot deletes a VolumeSnapshot. Snapshots that belong to a VM snapshot or backup
+// are refused, since removing them would break it; delete the VM snapshot or backup instead.
+func (h *ResourceHandler) DeleteVolumeSnapshot(ctx context.Context, namespace, name string) error {
+	gvr := ResourceTypeToGVR[ResourceTypeVolumeSnapshot]
+	snapshot, err := h.GetResource(ctx, gvr, namespace, name)
+	if err != nil {
+		return fmt.Errorf("failed to get volume snapshot %s/%s: %w", namespace, name, err)
+	}
+
+	for _, owner := range snapshot.GetOwnerReferences() {
+		if owner.Kind == "VirtualMachineBackup" {
+			return fmt.Errorf("volume snapshot %s/%s belongs to VM snapshot or backup %s, delete that instead", namespace, name, owner.Name)
+		}
+	}
+
+	if err := h.DeleteResource(ctx, gvr, namespace, name); err != nil {
+		return fmt.Errorf("failed to delete volume snapshot %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
@@ -0,0 +1,316 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
+)
+
+// longhornNamespace is the namespace holding the Longhorn resources backing Harvester volumes.
+const longhornNamespace = "longhorn-system"
+
+// Defaults matching the volumes the Harvester UI creates for VM disks
+const (
+	defaultVolumeAccessMode = "ReadWriteMany"
+	defaultVolumeMode       = "Block"
+)
+
+// VolumeDetails is a volume claim together with its backing Longhorn volume and the VMs using it.
+type VolumeDetails struct {
+	PVC *unstructured.Unstructured
+	// LonghornVolume is the Longhorn volume backing the claim, nil for claims not provisioned by Longhorn.
+	LonghornVolume *unstructured.Unstructured
+	// VMs lists the names of the virtual machines using the claim.
+	VMs []string
+}
+
+// VolumeCreateOptions describes a PersistentVolumeClaim to be created for use as a VM disk.
+type VolumeCreateOptions struct {
+	Name      string
+	Namespace string
+	Size      string
+	// StorageClass is the storage class of the volume; the cluster default is used when empty.
+	StorageClass string
+	AccessMode   string
+	VolumeMode   string
+	// Image is the image to populate the volume from, by name, display name or namespace/name;
+	// the volume is blank when empty.
+	Image string
+}
+
+// CreateVolume creates a PersistentVolumeClaim suitable for use as a VM disk, either blank or
+// populated from an image. Image-backed volumes use the storage class of the image, which is
+// how Longhorn provisions them from the image's backing image.
+func (h *ResourceHandler) CreateVolume(ctx context.Context, opts VolumeCreateOptions) (*unstructured.Unstructured, error) {
+	if _, err := resource.ParseQuantity(opts.Size); err != nil {
+		return nil, fmt.Errorf("invalid volume size %q: %w", opts.Size, err)
+	}
+
+	annotations := map[string]interface{}{}
+	if opts.Image != "" {
+		image, err := h.resolveImage(ctx, opts.Namespace, opts.Image)
+		if err != nil {
+			return nil, err
+		}
+		imageStorageClass := getNestedString(image.Object, "status", "storageClassName")
+		if imageStorageClass == "" {
+			return nil, fmt.Errorf("image %s/%s has no storage class yet, it may not be ready", image.GetNamespace(), image.GetName())
+		}
+		if opts.StorageClass != "" && opts.StorageClass != imageStorageClass {
+			return nil, fmt.Errorf("volumes from image %s/%s must use its storage class %s", image.GetNamespace(), image.GetName(), imageStorageClass)
+		}
+		opts.StorageClass = imageStorageClass
+		annotations[annotationImageID] = image.GetNamespace() + "/" + image.GetName()
+	}
+
+	accessMode := opts.AccessMode
+	if accessMode == "" {
+		accessMode = defaultVolumeAccessMode
+	}
+	volumeMode := opts.VolumeMode
+	if volumeMode == "" {
+		volumeMode = defaultVolumeMode
+	}
+
+	spec := map[string]interface{}{
+		"accessModes": []interface{}{accessMode},
+		"volumeMode":  volumeMode,
+		"resources": map[string]interface{}{
+			"requests": map[string]interface{}{"storage": opts.Size},
+		},
+	}
+	if opts.StorageClass != "" {
+		spec["storageClassName"] = opts.StorageClass
+	}
+
+	pvc := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "PersistentVolumeClaim",
+			"metadata": map[string]interface{}{
+				"name":      opts.Name,
+				"namespace": opts.Namespace,
+			},
+			"spec": spec,
+		},
+	}
+	if len(annotations) > 0 {
+		pvc.Object["metadata"].(map[string]interface{})["annotations"] = annotations
+	}
+
+	return h.CreateResource(ctx, ResourceTypeToGVR[ResourceTypePVC], opts.Namespace, pvc)
+}
+
+// ExpandVolume grows a volume claim to the given size. The storage class of the claim must allow
+// volume expansion, and volumes can only grow.
+func (h *ResourceHandler) ExpandVolume(ctx context.Context, namespace, name, size string) (*VolumeDetails, error) {
+	newSize, err := resource.ParseQuantity(size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid volume size %q: %w", size, err)
+	}
+
+	gvr := ResourceTypeToGVR[ResourceTypePVC]
+	pvc, err := h.GetResource(ctx, gvr, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volume %s/%s: %w", namespace, name, err)
+	}
+
+	if currentSize, err := resource.ParseQuantity(getNestedString(pvc.Object, "spec", "resources", "requests", "storage")); err == nil && newSize.Cmp(currentSize) <= 0 {
+		return nil, fmt.Errorf("volume %s/%s can only be expanded, requested size %s is not larger than the current size %s", namespace, name, newSize.String(), currentSize.String())
+	}
+
+	storageClassName := getNestedString(pvc.Object, "spec", "storageClassName")
+	if storageClassName == "" {
+		return nil, fmt.Errorf("volume %s/%s has no storage class, cannot check whether it can be expanded", namespace, name)
+	}
+	storageClass, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeStorageClass], "", storageClassName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage class %s: %w", storageClassName, err)
+	}
+	if !getNestedBool(storageClass.Object, "allowVolumeExpansion") {
+		return nil, fmt.Errorf("storage class %s of volume %s/%s does not allow volume expansion", storageClassName, namespace, name)
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		pvc, err := h.GetResource(ctx, gvr, namespace, name)
+		if err != nil {
+			return err
+		}
+		if err := unstructured.SetNestedField(pvc.Object, newSize.String(), "spec", "resources", "requests", "storage"); err != nil {
+			return err
+		}
+		_, err = h.UpdateResource(ctx, gvr, namespace, pvc)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand volume %s/%s: %w", namespace, name, err)
+	}
+
+	return h.GetVolume(ctx, namespace, name)
+}
+
+// CloneVolume creates a new volume claim in the same namespace as a copy of an existing one,
+// with the same size, storage class, access modes and volume mode.
+func (h *ResourceHandler) CloneVolume(ctx context.Context, namespace, sourceName, name string) (*unstructured.Unstructured, error) {
+	source, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypePVC], namespace, sourceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volume %s/%s: %w", namespace, sourceName, err)
+	}
+
+	spec := map[string]interface{}{
+		"dataSource": map[string]interface{}{
+			"kind": "PersistentVolumeClaim",
+			"name": sourceName,
+		},
+		"resources": map[string]interface{}{
+			"requests": map[string]interface{}{
+				"storage": getNestedString(source.Object, "spec", "resources", "requests", "storage"),
+			},
+		},
+	}
+	if accessModes, found, _ := unstructured.NestedSlice(source.Object, "spec", "accessModes"); found {
+		spec["accessModes"] = accessModes
+	}
+	for _, field := range []string{"storageClassName", "volumeMode"} {
+		if value := getNestedString(source.Object, "spec", field); value != "" {
+			spec[field] = value
+		}
+	}
+
+	metadata := map[string]interface{}{
+		"name":      name,
+		"namespace": namespace,
+	}
+	// Keep the image reference so the clone is still recognised as provisioned from the image
+	if imageID := source.GetAnnotations()[annotationImageID]; imageID != "" {
+		metadata["annotations"] = map[string]interface{}{annotationImageID: imageID}
+	}
+
+	pvc := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "PersistentVolumeClaim",
+			"metadata":   metadata,
+			"spec":       spec,
+		},
+	}
+
+	return h.CreateResource(ctx, ResourceTypeToGVR[ResourceTypePVC], namespace, pvc)
+}
+
+// DeleteVolume deletes a volume claim. Volumes still used by a virtual machine are only deleted
+// when forced; the names of those VMs are returned.
+func (h *ResourceHandler) DeleteVolume(ctx context.Context, namespace, name string, force bool) ([]string, error) {
+	gvr := ResourceTypeToGVR[ResourceTypePVC]
+	if _, err := h.GetResource(ctx, gvr, namespace, name); err != nil {
+		return nil, fmt.Errorf("failed to get volume %s/%s: %w", namespace, name, err)
+	}
+
+	vmsByClaim, err := h.vmsByClaim(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	vms := vmsByClaim[namespace+"/"+name]
+	if len(vms) > 0 && !force {
+		return vms, fmt.Errorf("volume %s/%s is used by virtual machines %s; detach it or delete the VMs first, or force the deletion", namespace, name, strings.Join(vms, ", "))
+	}
+
+	if err := h.DeleteResource(ctx, gvr, namespace, name); err != nil {
+		return vms, fmt.Errorf("failed to delete volume %s/%s: %w", namespace, name, err)
+	}
+
+	return vms, nil
+}
+
+// GetVolume retrieves a volume claim with its Longhorn volume and the VMs using it.
+func (h *ResourceHandler) GetVolume(ctx context.Context, namespace, name string) (*VolumeDetails, error) {
+	pvc, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypePVC], namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	volume := &VolumeDetails{PVC: pvc}
+	if volumeName := getNestedString(pvc.Object, "spec", "volumeName"); volumeName != "" {
+		lhVolume, err := h.GetResource(ctx, ResourceTypeToGVR[ResourceTypeLonghornVolume], longhornNamespace, volumeName)
+		if err == nil {
+			volume.LonghornVolume = lhVolume
+		} else if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get Longhorn volume %s: %w", volumeName, err)
+		}
+	}
+
+	vmsByClaim, err := h.vmsByClaim(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	volume.VMs = vmsByClaim[namespace+"/"+name]
+
+	return volume, nil
+}
+
+// ListVolumes lists volume claims with their Longhorn volumes and the VMs using them.
+// Claims that are not provisioned by Longhorn are listed without Longhorn details.
+func (h *ResourceHandler) ListVolumes(ctx context.Context, namespace string) ([]VolumeDetails, error) {
+	list, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypePVCs], namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	lhVolumes, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeLonghornVolumes], longhornNamespace)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to list Longhorn volumes: %w", err)
+	}
+	lhVolumesByName := map[string]*unstructured.Unstructured{}
+	if lhVolumes != nil {
+		for i := range lhVolumes.Items {
+			lhVolumesByName[lhVolumes.Items[i].GetName()] = &lhVolumes.Items[i]
+		}
+	}
+
+	vmsByClaim, err := h.vmsByClaim(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := make([]VolumeDetails, 0, len(list.Items))
+	for i := range list.Items {
+		pvc := &list.Items[i]
+		volumes = append(volumes, VolumeDetails{
+			PVC:            pvc,
+			LonghornVolume: lhVolumesByName[getNestedString(pvc.Object, "spec", "volumeName")],
+			VMs:            vmsByClaim[pvc.GetNamespace()+"/"+pvc.GetName()],
+		})
+	}
+
+	return volumes, nil
+}
+
+// vmsByClaim maps "namespace/claim" keys to the names of the virtual machines using the claim.
+func (h *ResourceHandler) vmsByClaim(ctx context.Context, namespace string) (map[string][]string, error) {
+	vms, err := h.ListResources(ctx, ResourceTypeToGVR[ResourceTypeVMs], namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list virtual machines: %w", err)
+	}
+
+	vmsByClaim := map[string][]string{}
+	for _, vm := range vms.Items {
+		volumes, _, _ := unstructured.NestedSlice(vm.Object, "spec", "template", "spec", "volumes")
+		for _, volumeObj := range volumes {
+			volume, ok := volumeObj.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if claimName := getNestedString(volume, "persistentVolumeClaim", "claimName"); claimName != "" {
+				key := vm.GetNamespace() + "/" + claimName
+				vmsByClaim[key] = append(vmsByClaim[key], vm.GetName())
+			}
+		}
+	}
+
+	return vmsByClaim, nil
+}
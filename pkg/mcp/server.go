@@ -3,6 +3,10 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -15,8 +19,23 @@ import (
 type Config struct {
 	// KubeConfigPath is the path to the kubeconfig file.
 	KubeConfigPath string
+	// HarvesterAPIURL is the base URL of the Harvester API (optional, derived from the kubeconfig by default).
+	HarvesterAPIURL string
 }
 
+// Bounds for tools that wait for a resource to reach a desired state
+const (
+	defaultWaitTimeout = 5 * time.Minute
+	maxWaitTimeout     = 30 * time.Minute
+)
+
+// Bounds for tools that read a VM serial console
+const (
+	defaultConsoleDuration = 5 * time.Second
+	maxConsoleDuration     = 60 * time.Second
+	defaultConsoleLines    = 50
+)
+
 // HarvesterMCPServer represents the MCP server for Harvester HCI.
 type HarvesterMCPServer struct {
 	mcpServer       *server.MCPServer
@@ -28,7 +47,8 @@ type HarvesterMCPServer struct {
 func NewServer(cfg *Config) (*HarvesterMCPServer, error) {
 	// Create client configuration
 	clientCfg := &client.Config{
-		KubeConfigPath: cfg.KubeConfigPath,
+		KubeConfigPath:  cfg.KubeConfigPath,
+		HarvesterAPIURL: cfg.HarvesterAPIURL,
 	}
 
 	// Create Kubernetes client
@@ -79,9 +99,20 @@ func (s *HarvesterMCPServer) registerTools() {
 
 	// Register Harvester-specific tools
 	s.registerHarvesterVirtualMachineTools()
+	s.registerHarvesterVMMigrationTools()
+	s.registerHarvesterVMSnapshotTools()
+	s.registerHarvesterVMBackupTools()
+	s.registerHarvesterVMVolumeHotplugTools()
+	s.registerHarvesterVMConsoleTools()
+	s.registerHarvesterVMTemplateTools()
 	s.registerHarvesterImageTools()
 	s.registerHarvesterVolumeTools()
+	s.registerHarvesterVolumeSnapshotTools()
+	s.registerStorageClassTools()
 	s.registerHarvesterNetworkTools()
+	s.registerHarvesterClusterNetworkTools()
+	s.registerHarvesterLoadBalancerTools()
+	s.registerHarvesterNodeMaintenanceTools()
 }
 
 // registerKubernetesPodTools registers Pod-related tools.
@@ -398,6 +429,58 @@ func (s *HarvesterMCPServer) registerKubernetesNodeTools() {
 		formatted := s.resourceHandler.FormatResource(resource, gvr)
 		return mcp.NewToolResultText(formatted), nil
 	})
+
+	// Cordon node tool
+	cordonNodeTool := mcp.NewTool(
+		"cordon_node",
+		mcp.WithDescription("Cordon a node so that no new VMs or pods are scheduled on it; running VMs stay where they are"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the node"),
+		),
+	)
+	s.mcpServer.AddTool(cordonNodeTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Node name is required"), nil
+		}
+
+		resource, err := s.resourceHandler.CordonNode(ctx, name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to cordon node %s: %v", name, err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeNode]
+		formatted := s.resourceHandler.FormatResource(resource, gvr)
+		return mcp.NewToolResultText(fmt.Sprintf("Node %s cordoned successfully\n\n%s", name, formatted)), nil
+	})
+
+	// Uncordon node tool
+	uncordonNodeTool := mcp.NewTool(
+		"uncordon_node",
+		mcp.WithDescription("Uncordon a node so that VMs and pods can be scheduled on it again; nodes in maintenance mode must have it disabled instead"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the node"),
+		),
+	)
+	s.mcpServer.AddTool(uncordonNodeTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Node name is required"), nil
+		}
+
+		resource, err := s.resourceHandler.UncordonNode(ctx, name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to uncordon node %s: %v", name, err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeNode]
+		formatted := s.resourceHandler.FormatResource(resource, gvr)
+		return mcp.NewToolResultText(fmt.Sprintf("Node %s uncordoned successfully\n\n%s", name, formatted)), nil
+	})
 }
 
 // registerKubernetesCRDTools registers CRD-related tools.
@@ -434,16 +517,12 @@ func (s *HarvesterMCPServer) registerHarvesterVirtualMachineTools() {
 	s.mcpServer.AddTool(listVMsTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		namespace, _ := req.Params.Arguments["namespace"].(string)
 
-		// Use the unified resource handler
-		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeVMs]
-		list, err := s.resourceHandler.ListResources(ctx, gvr, namespace)
+		vms, err := s.resourceHandler.ListVirtualMachines(ctx, namespace)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to list VMs: %v", err)), nil
 		}
 
-		// Format the list using the resource formatter
-		formatted := s.resourceHandler.FormatResourceList(list, gvr)
-		return mcp.NewToolResultText(formatted), nil
+		return mcp.NewToolResultText(kubernetes.FormatVMRuntimeList(vms)), nil
 	})
 
 	// Get VM tool
@@ -470,93 +549,2701 @@ func (s *HarvesterMCPServer) registerHarvesterVirtualMachineTools() {
 			return mcp.NewToolResultError("VM name is required"), nil
 		}
 
-		// Use the unified resource handler
-		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeVM]
-		resource, err := s.resourceHandler.GetResource(ctx, gvr, namespace, name)
+		vm, err := s.resourceHandler.GetVirtualMachine(ctx, namespace, name)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get VM %s in namespace %s: %v", name, namespace, err)), nil
 		}
 
+		return mcp.NewToolResultText(kubernetes.FormatVMRuntime(vm)), nil
+	})
+
+	// Create VM tool
+	createVMTool := mcp.NewTool(
+		"create_vm",
+		mcp.WithDescription("Create a Virtual Machine from an image in the Harvester cluster"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace to create the VM in"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the VM"),
+		),
+		mcp.WithNumber("cpu",
+			mcp.Required(),
+			mcp.Description("The number of CPU cores, a whole number"),
+		),
+		mcp.WithString("memory",
+			mcp.Required(),
+			mcp.Description("The amount of memory, e.g. 4Gi"),
+		),
+		mcp.WithString("image",
+			mcp.Required(),
+			mcp.Description("The image to boot from, by name or display name (use namespace/name for images in other namespaces)"),
+		),
+		mcp.WithString("disk_size",
+			mcp.Description("The size of the root disk (optional, defaults to 10Gi)"),
+		),
+		mcp.WithString("storage_class",
+			mcp.Description("The storage class of the root disk (optional, defaults to the image's storage class)"),
+		),
+		mcp.WithString("networks",
+			mcp.Description("Comma-separated list of VM networks in namespace/name form (optional, defaults to the pod network)"),
+		),
+		mcp.WithString("ssh_keys",
+			mcp.Description("Comma-separated list of SSH key pair names to inject (optional, requires #cloud-config user data)"),
+		),
+		mcp.WithString("user_data",
+			mcp.Description("Cloud-init user data (optional, defaults to installing the QEMU guest agent)"),
+		),
+	)
+	s.mcpServer.AddTool(createVMTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("VM name is required"), nil
+		}
+
+		cpu, ok := req.Params.Arguments["cpu"].(float64)
+		if !ok || cpu < 1 || cpu != math.Trunc(cpu) {
+			return mcp.NewToolResultError("CPU cores must be a whole number of at least 1"), nil
+		}
+
+		memory, ok := req.Params.Arguments["memory"].(string)
+		if !ok || memory == "" {
+			return mcp.NewToolResultError("Memory is required"), nil
+		}
+
+		image, ok := req.Params.Arguments["image"].(string)
+		if !ok || image == "" {
+			return mcp.NewToolResultError("Image is required"), nil
+		}
+
+		diskSize, _ := req.Params.Arguments["disk_size"].(string)
+		if diskSize == "" {
+			diskSize = "10Gi"
+		}
+		storageClass, _ := req.Params.Arguments["storage_class"].(string)
+		userData, _ := req.Params.Arguments["user_data"].(string)
+
+		opts := kubernetes.VMCreateOptions{
+			Name:         name,
+			Namespace:    namespace,
+			CPUCores:     int64(cpu),
+			Memory:       memory,
+			Image:        image,
+			DiskSize:     diskSize,
+			StorageClass: storageClass,
+			Networks:     getListArgument(req, "networks"),
+			SSHKeys:      getListArgument(req, "ssh_keys"),
+			UserData:     userData,
+		}
+
+		resource, err := s.resourceHandler.CreateVirtualMachine(ctx, opts)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to create VM %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
 		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeVM]
 		formatted := s.resourceHandler.FormatResource(resource, gvr)
-		return mcp.NewToolResultText(formatted), nil
+		return mcp.NewToolResultText(fmt.Sprintf("VM %s in namespace %s created successfully\n\n%s", name, namespace, formatted)), nil
 	})
-}
 
-// registerHarvesterImageTools registers Harvester Image-related tools.
-func (s *HarvesterMCPServer) registerHarvesterImageTools() {
-	// List images tool
-	listImagesTool := mcp.NewTool(
-		"list_images",
-		mcp.WithDescription("List Images in the Harvester cluster"),
+	// Update VM resources tool
+	updateVMResourcesTool := mcp.NewTool(
+		"update_vm_resources",
+		mcp.WithDescription("Change the CPU and memory of a Virtual Machine, optionally restarting it to apply the change"),
 		mcp.WithString("namespace",
-			mcp.Description("The namespace to list images from (optional, defaults to all namespaces)"),
+			mcp.Required(),
+			mcp.Description("The namespace of the VM"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the VM"),
+		),
+		mcp.WithNumber("cpu_cores",
+			mcp.Description("The number of CPU cores per socket (optional)"),
+		),
+		mcp.WithNumber("cpu_sockets",
+			mcp.Description("The number of CPU sockets (optional)"),
+		),
+		mcp.WithNumber("cpu_threads",
+			mcp.Description("The number of threads per CPU core (optional)"),
+		),
+		mcp.WithString("memory",
+			mcp.Description("The amount of memory, e.g. 8Gi (optional)"),
+		),
+		mcp.WithBoolean("restart",
+			mcp.Description("Restart the VM if the change cannot be applied while it is running (optional, defaults to false)"),
+		),
+		mcp.WithNumber("timeout",
+			mcp.Description(fmt.Sprintf("Seconds to wait for the restart to complete (optional, defaults to %d, maximum %d)", int(defaultWaitTimeout.Seconds()), int(maxWaitTimeout.Seconds()))),
 		),
 	)
-	s.mcpServer.AddTool(listImagesTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		namespace, _ := req.Params.Arguments["namespace"].(string)
+	s.mcpServer.AddTool(updateVMResourcesTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
 
-		// Use the unified resource handler
-		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeImages]
-		list, err := s.resourceHandler.ListResources(ctx, gvr, namespace)
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("VM name is required"), nil
+		}
+
+		cores, _ := req.Params.Arguments["cpu_cores"].(float64)
+		sockets, _ := req.Params.Arguments["cpu_sockets"].(float64)
+		threads, _ := req.Params.Arguments["cpu_threads"].(float64)
+		memory, _ := req.Params.Arguments["memory"].(string)
+		restart, _ := req.Params.Arguments["restart"].(bool)
+
+		opts := kubernetes.VMResourceOptions{
+			Cores:   int64(cores),
+			Sockets: int64(sockets),
+			Threads: int64(threads),
+			Memory:  memory,
+		}
+
+		result, err := s.resourceHandler.UpdateVMResources(ctx, namespace, name, opts)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to list images: %v", err)), nil
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to update resources of VM %s in namespace %s: %v", name, namespace, err)), nil
 		}
 
-		// Format the list using the resource formatter
-		formatted := s.resourceHandler.FormatResourceList(list, gvr)
-		return mcp.NewToolResultText(formatted), nil
+		message := fmt.Sprintf("Resources of VM %s in namespace %s updated successfully", name, namespace)
+		vm := result.VM
+		switch {
+		case result.RestartRequired && restart:
+			actionResult, err := s.resourceHandler.PerformVMAction(ctx, namespace, name, kubernetes.VMActionRestart, getTimeoutArgument(req))
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("%s, but the restart failed: %v", message, err)), nil
+			}
+			vm = actionResult.VM
+			message += fmt.Sprintf(" and the VM was restarted (instance phase: %s)", actionResult.VMIPhase)
+		case result.RestartRequired:
+			message += "; a restart is required for the change to take effect"
+		default:
+			message += "; no restart is required"
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeVM]
+		formatted := s.resourceHandler.FormatResource(vm, gvr)
+		return mcp.NewToolResultText(fmt.Sprintf("%s\n\n%s", message, formatted)), nil
 	})
-}
 
-// registerHarvesterVolumeTools registers Harvester Volume-related tools.
-func (s *HarvesterMCPServer) registerHarvesterVolumeTools() {
-	// List volumes tool
-	listVolumesTool := mcp.NewTool(
-		"list_volumes",
-		mcp.WithDescription("List Volumes in the Harvester cluster"),
+	// Clone VM tool
+	cloneVMTool := mcp.NewTool(
+		"clone_vm",
+		mcp.WithDescription("Clone a Virtual Machine to a new name and namespace with new MAC addresses, hostname and cloud-init instance ID"),
 		mcp.WithString("namespace",
-			mcp.Description("The namespace to list volumes from (optional, defaults to all namespaces)"),
+			mcp.Required(),
+			mcp.Description("The namespace of the VM to clone"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the VM to clone"),
+		),
+		mcp.WithString("new_name",
+			mcp.Required(),
+			mcp.Description("The name of the clone"),
+		),
+		mcp.WithString("new_namespace",
+			mcp.Description("The namespace of the clone (optional, defaults to the namespace of the VM; disks can only be cloned within the same namespace)"),
+		),
+		mcp.WithString("clone_method",
+			mcp.Description("How to populate the disks of the clone: csi clones each volume directly, snapshot restores each volume from a new volume snapshot, none provisions image-based disks from their image and other disks blank (optional, defaults to csi)"),
+			mcp.Enum(string(kubernetes.VMCloneMethodCSI), string(kubernetes.VMCloneMethodSnapshot), string(kubernetes.VMCloneMethodNone)),
+		),
+		mcp.WithBoolean("start",
+			mcp.Description("Start the clone once it is created (optional, defaults to false)"),
+		),
+		mcp.WithNumber("timeout",
+			mcp.Description(fmt.Sprintf("Seconds to wait for volume snapshots to become ready (optional, defaults to %d, maximum %d)", int(defaultWaitTimeout.Seconds()), int(maxWaitTimeout.Seconds()))),
 		),
 	)
-	s.mcpServer.AddTool(listVolumesTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		namespace, _ := req.Params.Arguments["namespace"].(string)
+	s.mcpServer.AddTool(cloneVMTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
 
-		// Use the unified resource handler
-		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeVolumes]
-		list, err := s.resourceHandler.ListResources(ctx, gvr, namespace)
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("VM name is required"), nil
+		}
+
+		newName, ok := req.Params.Arguments["new_name"].(string)
+		if !ok || newName == "" {
+			return mcp.NewToolResultError("New VM name is required"), nil
+		}
+
+		newNamespace, _ := req.Params.Arguments["new_namespace"].(string)
+		if newNamespace == "" {
+			newNamespace = namespace
+		}
+		method, _ := req.Params.Arguments["clone_method"].(string)
+		start, _ := req.Params.Arguments["start"].(bool)
+
+		result, err := s.resourceHandler.CloneVirtualMachine(ctx, kubernetes.VMCloneOptions{
+			SourceNamespace: namespace,
+			SourceName:      name,
+			Namespace:       newNamespace,
+			Name:            newName,
+			Method:          kubernetes.VMCloneMethod(method),
+			Start:           start,
+			Timeout:         getTimeoutArgument(req),
+		})
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to list volumes: %v", err)), nil
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to clone VM %s in namespace %s: %v", name, namespace, err)), nil
 		}
 
-		// Format the list using the resource formatter
-		formatted := s.resourceHandler.FormatResourceList(list, gvr)
-		return mcp.NewToolResultText(formatted), nil
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("VM %s in namespace %s cloned successfully to %s in namespace %s\n", name, namespace, newName, newNamespace))
+
+		claimNames := make([]string, 0, len(result.Volumes))
+		for claimName := range result.Volumes {
+			claimNames = append(claimNames, claimName)
+		}
+		sort.Strings(claimNames)
+		if len(claimNames) > 0 {
+			sb.WriteString("\nVolumes:\n")
+			for _, claimName := range claimNames {
+				sb.WriteString(fmt.Sprintf("  %s: %s\n", claimName, result.Volumes[claimName]))
+			}
+		}
+		if len(result.Snapshots) > 0 {
+			sb.WriteString(fmt.Sprintf("\nVolume snapshots taken (safe to delete once the clone's volumes are bound): %s\n", strings.Join(result.Snapshots, ", ")))
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeVM]
+		formatted := s.resourceHandler.FormatResource(result.VM, gvr)
+		return mcp.NewToolResultText(fmt.Sprintf("%s\n%s", sb.String(), formatted)), nil
 	})
-}
 
-// registerHarvesterNetworkTools registers Harvester Network-related tools.
-func (s *HarvesterMCPServer) registerHarvesterNetworkTools() {
-	// List networks tool
-	listNetworksTool := mcp.NewTool(
-		"list_networks",
-		mcp.WithDescription("List Networks in the Harvester cluster"),
+	// Get VM guest info tool
+	getVMGuestInfoTool := mcp.NewTool(
+		"get_vm_guest_info",
+		mcp.WithDescription("Get in-guest information of a running Virtual Machine from its guest agent: OS, users, filesystems and IP addresses"),
 		mcp.WithString("namespace",
-			mcp.Description("The namespace to list networks from (optional, defaults to all namespaces)"),
+			mcp.Required(),
+			mcp.Description("The namespace of the VM"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the VM"),
 		),
 	)
-	s.mcpServer.AddTool(listNetworksTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		namespace, _ := req.Params.Arguments["namespace"].(string)
+	s.mcpServer.AddTool(getVMGuestInfoTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
 
-		// Use the unified resource handler
-		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeNetworks]
-		list, err := s.resourceHandler.ListResources(ctx, gvr, namespace)
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("VM name is required"), nil
+		}
+
+		info, err := s.resourceHandler.GetVMGuestInfo(ctx, namespace, name)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to list networks: %v", err)), nil
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get guest info of VM %s in namespace %s: %v", name, namespace, err)), nil
 		}
 
-		// Format the list using the resource formatter
-		formatted := s.resourceHandler.FormatResourceList(list, gvr)
-		return mcp.NewToolResultText(formatted), nil
+		return mcp.NewToolResultText(kubernetes.FormatVMGuestInfo(info)), nil
+	})
+
+	// Delete VM tool
+	deleteVMTool := mcp.NewTool(
+		"delete_vm",
+		mcp.WithDescription("Delete a Virtual Machine, choosing which of its disks to delete with it; all other disks are kept as volumes"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the VM"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the VM"),
+		),
+		mcp.WithString("remove_disks",
+			mcp.Description("Comma-separated list of disk names or PVC names to delete with the VM (optional, defaults to keeping all disks)"),
+		),
+		mcp.WithBoolean("remove_all_disks",
+			mcp.Description("Delete all disks of the VM (optional, defaults to false)"),
+		),
+		mcp.WithNumber("timeout",
+			mcp.Description(fmt.Sprintf("Seconds to wait for the VM and the removed disks to be deleted (optional, defaults to %d, maximum %d)", int(defaultWaitTimeout.Seconds()), int(maxWaitTimeout.Seconds()))),
+		),
+	)
+	s.mcpServer.AddTool(deleteVMTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("VM name is required"), nil
+		}
+
+		removeAllDisks, _ := req.Params.Arguments["remove_all_disks"].(bool)
+
+		result, err := s.resourceHandler.DeleteVirtualMachine(ctx, kubernetes.VMDeleteOptions{
+			Namespace:      namespace,
+			Name:           name,
+			RemoveDisks:    getListArgument(req, "remove_disks"),
+			RemoveAllDisks: removeAllDisks,
+			Timeout:        getTimeoutArgument(req),
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete VM %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("VM %s in namespace %s deleted successfully\n", name, namespace))
+		writeVolumes := func(title string, volumes []kubernetes.VMDiskVolume) {
+			sb.WriteString(fmt.Sprintf("\n%s (%d):\n", title, len(volumes)))
+			for _, volume := range volumes {
+				sb.WriteString(fmt.Sprintf("  • %s (disk %s)\n", volume.ClaimName, volume.Disk))
+			}
+		}
+		writeVolumes("Removed Volumes", result.Removed)
+		writeVolumes("Remaining Volumes", result.Remaining)
+		if len(result.Pending) > 0 {
+			writeVolumes("Volumes Still Being Removed", result.Pending)
+		}
+
+		return mcp.NewToolResultText(sb.String()), nil
 	})
+
+	// VM power lifecycle tools
+	s.registerVMActionTool("start_vm", "Start a Virtual Machine and wait until it is running", kubernetes.VMActionStart, "started")
+	s.registerVMActionTool("stop_vm", "Stop a Virtual Machine and wait until its instance is gone", kubernetes.VMActionStop, "stopped")
+	s.registerVMActionTool("restart_vm", "Restart a Virtual Machine and wait until the new instance is running", kubernetes.VMActionRestart, "restarted")
+	s.registerVMActionTool("pause_vm", "Pause a running Virtual Machine and wait until it is paused", kubernetes.VMActionPause, "paused")
+	s.registerVMActionTool("unpause_vm", "Unpause a paused Virtual Machine and wait until it is running again", kubernetes.VMActionUnpause, "unpaused")
+}
+
+// registerVMActionTool registers a tool that performs a power lifecycle action on a VM.
+func (s *HarvesterMCPServer) registerVMActionTool(toolName, description string, action kubernetes.VMAction, pastTense string) {
+	tool := mcp.NewTool(
+		toolName,
+		mcp.WithDescription(description),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the VM"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the VM"),
+		),
+		mcp.WithNumber("timeout",
+			mcp.Description(fmt.Sprintf("Seconds to wait for the VM to reach the requested state (optional, defaults to %d, maximum %d)", int(defaultWaitTimeout.Seconds()), int(maxWaitTimeout.Seconds()))),
+		),
+	)
+	s.mcpServer.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("VM name is required"), nil
+		}
+
+		timeout := getTimeoutArgument(req)
+
+		result, err := s.resourceHandler.PerformVMAction(ctx, namespace, name, action, timeout)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to %s VM %s in namespace %s: %v", action, name, namespace, err)), nil
+		}
+
+		// Format the resulting VM using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeVM]
+		formatted := s.resourceHandler.FormatResource(result.VM, gvr)
+		return mcp.NewToolResultText(fmt.Sprintf("VM %s in namespace %s %s successfully (instance phase: %s)\n\n%s", name, namespace, pastTense, result.VMIPhase, formatted)), nil
+	})
+}
+
+// registerHarvesterVMMigrationTools registers Harvester VM live migration tools.
+func (s *HarvesterMCPServer) registerHarvesterVMMigrationTools() {
+	// Migrate VM tool
+	migrateVMTool := mcp.NewTool(
+		"migrate_vm",
+		mcp.WithDescription("Live migrate a running Virtual Machine to another node"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the VM"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the VM"),
+		),
+		mcp.WithString("target_node",
+			mcp.Description("The node to migrate the VM to (optional, defaults to any schedulable node)"),
+		),
+	)
+	s.mcpServer.AddTool(migrateVMTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("VM name is required"), nil
+		}
+
+		targetNode, _ := req.Params.Arguments["target_node"].(string)
+
+		migration, err := s.resourceHandler.MigrateVirtualMachine(ctx, namespace, name, targetNode)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to migrate VM %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeMigration]
+		formatted := s.resourceHandler.FormatResource(migration, gvr)
+		return mcp.NewToolResultText(fmt.Sprintf("Migration of VM %s in namespace %s started\n\n%s", name, namespace, formatted)), nil
+	})
+
+	// List VM migrations tool
+	listVMMigrationsTool := mcp.NewTool(
+		"list_vm_migrations",
+		mcp.WithDescription("List Virtual Machine live migrations in the Harvester cluster"),
+		mcp.WithString("namespace",
+			mcp.Description("The namespace to list migrations from (optional, defaults to all namespaces)"),
+		),
+		mcp.WithString("vm",
+			mcp.Description("Only list migrations of this VM (optional)"),
+		),
+	)
+	s.mcpServer.AddTool(listVMMigrationsTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, _ := req.Params.Arguments["namespace"].(string)
+		vmName, _ := req.Params.Arguments["vm"].(string)
+
+		list, err := s.resourceHandler.ListVMMigrations(ctx, namespace, vmName)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list VM migrations: %v", err)), nil
+		}
+
+		// Format the list using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeMigrations]
+		formatted := s.resourceHandler.FormatResourceList(list, gvr)
+		return mcp.NewToolResultText(formatted), nil
+	})
+
+	// Cancel VM migration tool
+	cancelVMMigrationTool := mcp.NewTool(
+		"cancel_vm_migration",
+		mcp.WithDescription("Cancel the in-progress live migration of a Virtual Machine"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the VM"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the VM being migrated"),
+		),
+	)
+	s.mcpServer.AddTool(cancelVMMigrationTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("VM name is required"), nil
+		}
+
+		migration, err := s.resourceHandler.CancelVMMigration(ctx, namespace, name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to cancel migration of VM %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Migration %s of VM %s in namespace %s cancelled successfully", migration.GetName(), name, namespace)), nil
+	})
+}
+
+// registerHarvesterVMSnapshotTools registers Harvester VM snapshot tools.
+func (s *HarvesterMCPServer) registerHarvesterVMSnapshotTools() {
+	// Create VM snapshot tool
+	createVMSnapshotTool := mcp.NewTool(
+		"create_vm_snapshot",
+		mcp.WithDescription("Create a snapshot of a Virtual Machine"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the VM"),
+		),
+		mcp.WithString("vm",
+			mcp.Required(),
+			mcp.Description("The name of the VM to snapshot"),
+		),
+		mcp.WithString("name",
+			mcp.Description("The name of the snapshot (optional, generated from the VM name by default)"),
+		),
+	)
+	s.mcpServer.AddTool(createVMSnapshotTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		vmName, ok := req.Params.Arguments["vm"].(string)
+		if !ok || vmName == "" {
+			return mcp.NewToolResultError("VM name is required"), nil
+		}
+
+		name, _ := req.Params.Arguments["name"].(string)
+
+		snapshot, err := s.resourceHandler.CreateVMBackup(ctx, namespace, vmName, name, kubernetes.VMBackupTypeSnapshot)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to create snapshot of VM %s in namespace %s: %v", vmName, namespace, err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeVMBackup]
+		formatted := s.resourceHandler.FormatResource(snapshot, gvr)
+		return mcp.NewToolResultText(fmt.Sprintf("Snapshot %s of VM %s in namespace %s created successfully\n\n%s", snapshot.GetName(), vmName, namespace, formatted)), nil
+	})
+
+	// List VM snapshots tool
+	listVMSnapshotsTool := mcp.NewTool(
+		"list_vm_snapshots",
+		mcp.WithDescription("List Virtual Machine snapshots in the Harvester cluster"),
+		mcp.WithString("namespace",
+			mcp.Description("The namespace to list snapshots from (optional, defaults to all namespaces)"),
+		),
+		mcp.WithString("vm",
+			mcp.Description("Only list snapshots of this VM (optional)"),
+		),
+	)
+	s.mcpServer.AddTool(listVMSnapshotsTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, _ := req.Params.Arguments["namespace"].(string)
+		vmName, _ := req.Params.Arguments["vm"].(string)
+
+		list, err := s.resourceHandler.ListVMBackups(ctx, namespace, vmName, kubernetes.VMBackupTypeSnapshot)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list VM snapshots: %v", err)), nil
+		}
+		if len(list.Items) == 0 {
+			return mcp.NewToolResultText("No VM snapshots found in the specified namespace(s)."), nil
+		}
+
+		// Format the list using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeVMBackups]
+		formatted := s.resourceHandler.FormatResourceList(list, gvr)
+		return mcp.NewToolResultText(formatted), nil
+	})
+
+	// Restore VM snapshot tool
+	restoreVMSnapshotTool := mcp.NewTool(
+		"restore_vm_snapshot",
+		mcp.WithDescription("Restore a Virtual Machine snapshot, either in place (the VM must be stopped) or to a new VM"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the snapshot"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the snapshot to restore"),
+		),
+		mcp.WithString("new_vm_name",
+			mcp.Description("Restore into a new VM with this name (optional, defaults to replacing the source VM)"),
+		),
+		mcp.WithString("deletion_policy",
+			mcp.Description("What to do with the volumes replaced by an in-place restore (optional, defaults to retain)"),
+			mcp.Enum(kubernetes.VMRestoreDeletionPolicyRetain, kubernetes.VMRestoreDeletionPolicyDelete),
+		),
+	)
+	s.mcpServer.AddTool(restoreVMSnapshotTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Snapshot name is required"), nil
+		}
+
+		newVMName, _ := req.Params.Arguments["new_vm_name"].(string)
+		deletionPolicy, _ := req.Params.Arguments["deletion_policy"].(string)
+
+		restore, err := s.resourceHandler.RestoreVMBackup(ctx, namespace, name, kubernetes.VMBackupTypeSnapshot, newVMName, deletionPolicy)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to restore snapshot %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeVMRestore]
+		formatted := s.resourceHandler.FormatResource(restore, gvr)
+		return mcp.NewToolResultText(fmt.Sprintf("Restore of snapshot %s in namespace %s started\n\n%s", name, namespace, formatted)), nil
+	})
+
+	// Delete VM snapshot tool
+	deleteVMSnapshotTool := mcp.NewTool(
+		"delete_vm_snapshot",
+		mcp.WithDescription("Delete a Virtual Machine snapshot"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the snapshot"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the snapshot to delete"),
+		),
+	)
+	s.mcpServer.AddTool(deleteVMSnapshotTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Snapshot name is required"), nil
+		}
+
+		err := s.resourceHandler.DeleteVMBackup(ctx, namespace, name, kubernetes.VMBackupTypeSnapshot)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete snapshot %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Snapshot %s in namespace %s deleted successfully", name, namespace)), nil
+	})
+}
+
+// registerHarvesterVMBackupTools registers Harvester VM backup tools.
+func (s *HarvesterMCPServer) registerHarvesterVMBackupTools() {
+	// Create VM backup tool
+	createVMBackupTool := mcp.NewTool(
+		"create_vm_backup",
+		mcp.WithDescription("Back up a Virtual Machine to the configured backup target"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the VM"),
+		),
+		mcp.WithString("vm",
+			mcp.Required(),
+			mcp.Description("The name of the VM to back up"),
+		),
+		mcp.WithString("name",
+			mcp.Description("The name of the backup (optional, generated from the VM name by default)"),
+		),
+	)
+	s.mcpServer.AddTool(createVMBackupTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		vmName, ok := req.Params.Arguments["vm"].(string)
+		if !ok || vmName == "" {
+			return mcp.NewToolResultError("VM name is required"), nil
+		}
+
+		name, _ := req.Params.Arguments["name"].(string)
+
+		backup, err := s.resourceHandler.CreateVMBackup(ctx, namespace, vmName, name, kubernetes.VMBackupTypeBackup)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to create backup of VM %s in namespace %s: %v", vmName, namespace, err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeVMBackup]
+		formatted := s.resourceHandler.FormatResource(backup, gvr)
+		return mcp.NewToolResultText(fmt.Sprintf("Backup %s of VM %s in namespace %s created successfully\n\n%s", backup.GetName(), vmName, namespace, formatted)), nil
+	})
+
+	// List VM backups tool
+	listVMBackupsTool := mcp.NewTool(
+		"list_vm_backups",
+		mcp.WithDescription("List Virtual Machine backups with their backup target and progress"),
+		mcp.WithString("namespace",
+			mcp.Description("The namespace to list backups from (optional, defaults to all namespaces)"),
+		),
+		mcp.WithString("vm",
+			mcp.Description("Only list backups of this VM (optional)"),
+		),
+	)
+	s.mcpServer.AddTool(listVMBackupsTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, _ := req.Params.Arguments["namespace"].(string)
+		vmName, _ := req.Params.Arguments["vm"].(string)
+
+		list, err := s.resourceHandler.ListVMBackups(ctx, namespace, vmName, kubernetes.VMBackupTypeBackup)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list VM backups: %v", err)), nil
+		}
+		if len(list.Items) == 0 {
+			return mcp.NewToolResultText("No VM backups found in the specified namespace(s)."), nil
+		}
+
+		// Format the list using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeVMBackups]
+		formatted := s.resourceHandler.FormatResourceList(list, gvr)
+		return mcp.NewToolResultText(formatted), nil
+	})
+
+	// Restore VM backup tool
+	restoreVMBackupTool := mcp.NewTool(
+		"restore_vm_backup",
+		mcp.WithDescription("Restore a Virtual Machine backup, either replacing the existing VM (which must be stopped) or into a new VM"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the backup"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the backup to restore"),
+		),
+		mcp.WithString("new_vm_name",
+			mcp.Description("Restore into a new VM with this name (optional, defaults to replacing the existing VM)"),
+		),
+		mcp.WithString("deletion_policy",
+			mcp.Description("What to do with the volumes replaced when restoring the existing VM (optional, defaults to retain)"),
+			mcp.Enum(kubernetes.VMRestoreDeletionPolicyRetain, kubernetes.VMRestoreDeletionPolicyDelete),
+		),
+	)
+	s.mcpServer.AddTool(restoreVMBackupTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Backup name is required"), nil
+		}
+
+		newVMName, _ := req.Params.Arguments["new_vm_name"].(string)
+		deletionPolicy, _ := req.Params.Arguments["deletion_policy"].(string)
+
+		restore, err := s.resourceHandler.RestoreVMBackup(ctx, namespace, name, kubernetes.VMBackupTypeBackup, newVMName, deletionPolicy)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to restore backup %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeVMRestore]
+		formatted := s.resourceHandler.FormatResource(restore, gvr)
+		return mcp.NewToolResultText(fmt.Sprintf("Restore of backup %s in namespace %s started\n\n%s", name, namespace, formatted)), nil
+	})
+
+	// Get backup target tool
+	getBackupTargetTool := mcp.NewTool(
+		"get_backup_target",
+		mcp.WithDescription("Show the configured VM backup target and whether it is healthy"),
+	)
+	s.mcpServer.AddTool(getBackupTargetTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Use the unified resource handler
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeSetting]
+		resource, err := s.resourceHandler.GetResource(ctx, gvr, "", kubernetes.SettingBackupTarget)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get backup target setting: %v", err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		formatted := s.resourceHandler.FormatResource(resource, gvr)
+		return mcp.NewToolResultText(formatted), nil
+	})
+}
+
+// registerHarvesterVMVolumeHotplugTools registers tools for hotplugging volumes into running VMs.
+func (s *HarvesterMCPServer) registerHarvesterVMVolumeHotplugTools() {
+	// Attach volume tool
+	attachVolumeTool := mcp.NewTool(
+		"attach_volume_to_vm",
+		mcp.WithDescription("Hotplug an existing volume, or a new blank volume, into a Virtual Machine"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the VM"),
+		),
+		mcp.WithString("vm",
+			mcp.Required(),
+			mcp.Description("The name of the VM"),
+		),
+		mcp.WithString("volume",
+			mcp.Description("The PVC to attach; required unless size is given (optional, generated for new volumes by default)"),
+		),
+		mcp.WithString("size",
+			mcp.Description("Create a new blank volume of this size, e.g. 10Gi (optional)"),
+		),
+		mcp.WithString("storage_class",
+			mcp.Description("The storage class of the new volume (optional, defaults to the cluster default)"),
+		),
+		mcp.WithString("disk_name",
+			mcp.Description("The name of the disk inside the VM (optional, defaults to the volume name)"),
+		),
+	)
+	s.mcpServer.AddTool(attachVolumeTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		vmName, ok := req.Params.Arguments["vm"].(string)
+		if !ok || vmName == "" {
+			return mcp.NewToolResultError("VM name is required"), nil
+		}
+
+		claimName, _ := req.Params.Arguments["volume"].(string)
+		size, _ := req.Params.Arguments["size"].(string)
+		storageClass, _ := req.Params.Arguments["storage_class"].(string)
+		diskName, _ := req.Params.Arguments["disk_name"].(string)
+
+		opts := kubernetes.VMVolumeAttachOptions{
+			VolumeName:   diskName,
+			ClaimName:    claimName,
+			Size:         size,
+			StorageClass: storageClass,
+		}
+
+		vm, err := s.resourceHandler.AttachVolumeToVM(ctx, namespace, vmName, opts)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to attach volume to VM %s in namespace %s: %v", vmName, namespace, err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeVM]
+		formatted := s.resourceHandler.FormatResource(vm, gvr)
+		return mcp.NewToolResultText(fmt.Sprintf("Volume attach requested for VM %s in namespace %s\n\n%s", vmName, namespace, formatted)), nil
+	})
+
+	// Detach volume tool
+	detachVolumeTool := mcp.NewTool(
+		"detach_volume_from_vm",
+		mcp.WithDescription("Remove a hotplugged volume from a Virtual Machine, keeping the volume itself"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the VM"),
+		),
+		mcp.WithString("vm",
+			mcp.Required(),
+			mcp.Description("The name of the VM"),
+		),
+		mcp.WithString("disk_name",
+			mcp.Required(),
+			mcp.Description("The name of the hotplugged disk to remove"),
+		),
+	)
+	s.mcpServer.AddTool(detachVolumeTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		vmName, ok := req.Params.Arguments["vm"].(string)
+		if !ok || vmName == "" {
+			return mcp.NewToolResultError("VM name is required"), nil
+		}
+
+		diskName, ok := req.Params.Arguments["disk_name"].(string)
+		if !ok || diskName == "" {
+			return mcp.NewToolResultError("Disk name is required"), nil
+		}
+
+		vm, err := s.resourceHandler.DetachVolumeFromVM(ctx, namespace, vmName, diskName)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to detach volume %s from VM %s in namespace %s: %v", diskName, vmName, namespace, err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeVM]
+		formatted := s.resourceHandler.FormatResource(vm, gvr)
+		return mcp.NewToolResultText(fmt.Sprintf("Volume detach requested for VM %s in namespace %s\n\n%s", vmName, namespace, formatted)), nil
+	})
+}
+
+// registerHarvesterVMConsoleTools registers VM serial console tools.
+func (s *HarvesterMCPServer) registerHarvesterVMConsoleTools() {
+	// Console snapshot tool
+	consoleSnapshotTool := mcp.NewTool(
+		"vm_console_snapshot",
+		mcp.WithDescription("Capture serial console output of a running Virtual Machine for a short time, e.g. to see why it does not boot"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the VM"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the VM"),
+		),
+		mcp.WithNumber("duration",
+			mcp.Description(fmt.Sprintf("Seconds to capture output for (optional, defaults to %d, maximum %d)", int(defaultConsoleDuration.Seconds()), int(maxConsoleDuration.Seconds()))),
+		),
+		mcp.WithNumber("lines",
+			mcp.Description(fmt.Sprintf("The number of most recent lines to return (optional, defaults to %d)", defaultConsoleLines)),
+		),
+		mcp.WithBoolean("wake",
+			mcp.Description("Send Enter on connect so an idle console prints its prompt (optional, defaults to false)"),
+		),
+	)
+	s.mcpServer.AddTool(consoleSnapshotTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("VM name is required"), nil
+		}
+
+		input := ""
+		if wake, _ := req.Params.Arguments["wake"].(bool); wake {
+			input = "\r"
+		}
+
+		duration := getDurationArgument(req, "duration", defaultConsoleDuration, maxConsoleDuration)
+		output, err := s.resourceHandler.ReadVMConsole(ctx, namespace, name, input, duration, getLinesArgument(req))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to read console of VM %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		return mcp.NewToolResultText(formatConsoleOutput(name, namespace, duration, output)), nil
+	})
+
+	// Console input tool
+	consoleInputTool := mcp.NewTool(
+		"vm_send_console_input",
+		mcp.WithDescription("Send keystrokes to the serial console of a running Virtual Machine and capture the output that follows"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the VM"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the VM"),
+		),
+		mcp.WithString("input",
+			mcp.Required(),
+			mcp.Description("The text to type into the console"),
+		),
+		mcp.WithBoolean("enter",
+			mcp.Description("Press Enter after the input (optional, defaults to true)"),
+		),
+		mcp.WithNumber("duration",
+			mcp.Description(fmt.Sprintf("Seconds to capture output for after sending (optional, defaults to %d, maximum %d)", int(defaultConsoleDuration.Seconds()), int(maxConsoleDuration.Seconds()))),
+		),
+		mcp.WithNumber("lines",
+			mcp.Description(fmt.Sprintf("The number of most recent lines to return (optional, defaults to %d)", defaultConsoleLines)),
+		),
+	)
+	s.mcpServer.AddTool(consoleInputTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("VM name is required"), nil
+		}
+
+		input, ok := req.Params.Arguments["input"].(string)
+		if !ok || input == "" {
+			return mcp.NewToolResultError("Input is required"), nil
+		}
+
+		if enter, ok := req.Params.Arguments["enter"].(bool); !ok || enter {
+			input += "\r"
+		}
+
+		duration := getDurationArgument(req, "duration", defaultConsoleDuration, maxConsoleDuration)
+		output, err := s.resourceHandler.ReadVMConsole(ctx, namespace, name, input, duration, getLinesArgument(req))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to send input to console of VM %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		return mcp.NewToolResultText(formatConsoleOutput(name, namespace, duration, output)), nil
+	})
+}
+
+// registerHarvesterVMTemplateTools registers Harvester VM template tools.
+func (s *HarvesterMCPServer) registerHarvesterVMTemplateTools() {
+	// List VM templates tool
+	listVMTemplatesTool := mcp.NewTool(
+		"list_vm_templates",
+		mcp.WithDescription("List Virtual Machine templates with their default and latest versions"),
+		mcp.WithString("namespace",
+			mcp.Description("The namespace to list templates from (optional, defaults to all namespaces)"),
+		),
+	)
+	s.mcpServer.AddTool(listVMTemplatesTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, _ := req.Params.Arguments["namespace"].(string)
+
+		list, err := s.resourceHandler.ListVMTemplates(ctx, namespace)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list VM templates: %v", err)), nil
+		}
+
+		// Format the list using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeTemplates]
+		formatted := s.resourceHandler.FormatResourceList(list, gvr)
+		return mcp.NewToolResultText(formatted), nil
+	})
+
+	// Get VM template version tool
+	getVMTemplateVersionTool := mcp.NewTool(
+		"get_vm_template_version",
+		mcp.WithDescription("Get a Virtual Machine template version including the full VM spec it creates"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the template"),
+		),
+		mcp.WithString("template",
+			mcp.Required(),
+			mcp.Description("The name of the template"),
+		),
+		mcp.WithNumber("version",
+			mcp.Description("The version number (optional, defaults to the template's default version)"),
+		),
+	)
+	s.mcpServer.AddTool(getVMTemplateVersionTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		template, ok := req.Params.Arguments["template"].(string)
+		if !ok || template == "" {
+			return mcp.NewToolResultError("Template name is required"), nil
+		}
+
+		version, _ := req.Params.Arguments["version"].(float64)
+		if version < 0 {
+			return mcp.NewToolResultError("Version must not be negative"), nil
+		}
+
+		resource, err := s.resourceHandler.GetVMTemplateVersion(ctx, namespace, template, int64(version))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get version of template %s in namespace %s: %v", template, namespace, err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeTemplateVersion]
+		formatted := s.resourceHandler.FormatResource(resource, gvr)
+		return mcp.NewToolResultText(formatted), nil
+	})
+
+	// Create VM from template tool
+	createVMFromTemplateTool := mcp.NewTool(
+		"create_vm_from_template",
+		mcp.WithDescription("Create a Virtual Machine from a template version, optionally overriding CPU, memory, cloud-init user data and whether it starts"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace to create the VM in"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the VM"),
+		),
+		mcp.WithString("template",
+			mcp.Required(),
+			mcp.Description("The template to create the VM from (use namespace/name for templates in other namespaces)"),
+		),
+		mcp.WithNumber("version",
+			mcp.Description("The template version number (optional, defaults to the template's default version)"),
+		),
+		mcp.WithNumber("cpu",
+			mcp.Description("Override the number of CPU cores (optional)"),
+		),
+		mcp.WithString("memory",
+			mcp.Description("Override the amount of memory, e.g. 4Gi (optional)"),
+		),
+		mcp.WithString("user_data",
+			mcp.Description("Override the cloud-init user data (optional)"),
+		),
+		mcp.WithBoolean("start",
+			mcp.Description("Whether to start the VM after creation (optional, defaults to the template's run strategy)"),
+		),
+	)
+	s.mcpServer.AddTool(createVMFromTemplateTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("VM name is required"), nil
+		}
+
+		template, ok := req.Params.Arguments["template"].(string)
+		if !ok || template == "" {
+			return mcp.NewToolResultError("Template name is required"), nil
+		}
+
+		version, _ := req.Params.Arguments["version"].(float64)
+		cpu, _ := req.Params.Arguments["cpu"].(float64)
+		if version < 0 || cpu < 0 {
+			return mcp.NewToolResultError("Version and CPU cores must not be negative"), nil
+		}
+		memory, _ := req.Params.Arguments["memory"].(string)
+		userData, _ := req.Params.Arguments["user_data"].(string)
+
+		opts := kubernetes.VMFromTemplateOptions{
+			Name:      name,
+			Namespace: namespace,
+			Template:  template,
+			Version:   int64(version),
+			CPUCores:  int64(cpu),
+			Memory:    memory,
+			UserData:  userData,
+		}
+		if start, ok := req.Params.Arguments["start"].(bool); ok {
+			opts.Start = &start
+		}
+
+		resource, err := s.resourceHandler.CreateVMFromTemplate(ctx, opts)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to create VM %s from template %s in namespace %s: %v", name, template, namespace, err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeVM]
+		formatted := s.resourceHandler.FormatResource(resource, gvr)
+		return mcp.NewToolResultText(fmt.Sprintf("VM %s in namespace %s created successfully from template %s\n\n%s", name, namespace, template, formatted)), nil
+	})
+
+	// Save VM as template tool
+	saveVMAsTemplateTool := mcp.NewTool(
+		"save_vm_as_template",
+		mcp.WithDescription("Save the configuration of an existing Virtual Machine as a new template version. Disk contents are not copied: image-based disks are provisioned from the same image and other disks are created blank"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the VM and the template"),
+		),
+		mcp.WithString("vm",
+			mcp.Required(),
+			mcp.Description("The name of the VM to save"),
+		),
+		mcp.WithString("template",
+			mcp.Required(),
+			mcp.Description("The name of the template, which is created if it does not exist"),
+		),
+		mcp.WithString("description",
+			mcp.Description("A description of the new version (optional)"),
+		),
+		mcp.WithBoolean("set_default",
+			mcp.Description("Make the new version the template's default version (optional, defaults to false; the first version always becomes the default)"),
+		),
+	)
+	s.mcpServer.AddTool(saveVMAsTemplateTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		vmName, ok := req.Params.Arguments["vm"].(string)
+		if !ok || vmName == "" {
+			return mcp.NewToolResultError("VM name is required"), nil
+		}
+
+		template, ok := req.Params.Arguments["template"].(string)
+		if !ok || template == "" {
+			return mcp.NewToolResultError("Template name is required"), nil
+		}
+
+		description, _ := req.Params.Arguments["description"].(string)
+		setDefault, _ := req.Params.Arguments["set_default"].(bool)
+
+		version, err := s.resourceHandler.SaveVMAsTemplate(ctx, kubernetes.VMTemplateSaveOptions{
+			Namespace:   namespace,
+			VMName:      vmName,
+			Template:    template,
+			Description: description,
+			SetDefault:  setDefault,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to save VM %s in namespace %s as template %s: %v", vmName, namespace, template, err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeTemplateVersion]
+		formatted := s.resourceHandler.FormatResource(version, gvr)
+		return mcp.NewToolResultText(fmt.Sprintf("VM %s in namespace %s saved as template %s successfully\n\n%s", vmName, namespace, template, formatted)), nil
+	})
+}
+
+// registerHarvesterImageTools registers Harvester Image-related tools.
+func (s *HarvesterMCPServer) registerHarvesterImageTools() {
+	// List images tool
+	listImagesTool := mcp.NewTool(
+		"list_images",
+		mcp.WithDescription("List Images in the Harvester cluster"),
+		mcp.WithString("namespace",
+			mcp.Description("The namespace to list images from (optional, defaults to all namespaces)"),
+		),
+	)
+	s.mcpServer.AddTool(listImagesTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, _ := req.Params.Arguments["namespace"].(string)
+
+		// Use the unified resource handler
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeImages]
+		list, err := s.resourceHandler.ListResources(ctx, gvr, namespace)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list images: %v", err)), nil
+		}
+
+		// Format the list using the resource formatter
+		formatted := s.resourceHandler.FormatResourceList(list, gvr)
+		return mcp.NewToolResultText(formatted), nil
+	})
+
+	// Get image tool
+	getImageTool := mcp.NewTool(
+		"get_image",
+		mcp.WithDescription("Get Image details including download or upload progress, checksum, storage class parameters and failures"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the image"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name or display name of the image"),
+		),
+	)
+	s.mcpServer.AddTool(getImageTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Image name is required"), nil
+		}
+
+		image, err := s.resourceHandler.GetImage(ctx, namespace, name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get image %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeImage]
+		formatted := s.resourceHandler.FormatResource(image, gvr)
+		return mcp.NewToolResultText(formatted), nil
+	})
+
+	// Create image from URL tool
+	createImageFromURLTool := mcp.NewTool(
+		"create_image_from_url",
+		mcp.WithDescription("Create an Image that Harvester downloads from a URL; use get_image to follow the download progress"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace to create the image in"),
+		),
+		mcp.WithString("url",
+			mcp.Required(),
+			mcp.Description("The URL to download the image from"),
+		),
+		mcp.WithString("display_name",
+			mcp.Description("The display name of the image (optional, defaults to the file name in the URL)"),
+		),
+		mcp.WithString("name",
+			mcp.Description("The resource name of the image (optional, generated by default)"),
+		),
+		mcp.WithString("checksum",
+			mcp.Description("The SHA-512 checksum to verify the download against (optional)"),
+		),
+		mcp.WithString("storage_class",
+			mcp.Description("The storage class whose parameters the image uses (optional, defaults to the default storage class)"),
+		),
+		mcp.WithString("description",
+			mcp.Description("A description of the image (optional)"),
+		),
+	)
+	s.mcpServer.AddTool(createImageFromURLTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		url, ok := req.Params.Arguments["url"].(string)
+		if !ok || url == "" {
+			return mcp.NewToolResultError("URL is required"), nil
+		}
+
+		opts := getImageCreateOptions(req, namespace)
+		opts.URL = url
+
+		image, err := s.resourceHandler.CreateImageFromURL(ctx, opts)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to create image in namespace %s: %v", namespace, err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeImage]
+		formatted := s.resourceHandler.FormatResource(image, gvr)
+		return mcp.NewToolResultText(fmt.Sprintf("Image %s in namespace %s created successfully, Harvester is downloading it\n\n%s", image.GetName(), namespace, formatted)), nil
+	})
+
+	// Upload image tool
+	uploadImageTool := mcp.NewTool(
+		"upload_image",
+		mcp.WithDescription("Create an Image by uploading a local file to the Harvester upload endpoint"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace to create the image in"),
+		),
+		mcp.WithString("file_path",
+			mcp.Required(),
+			mcp.Description("The path of the local image file to upload"),
+		),
+		mcp.WithString("display_name",
+			mcp.Description("The display name of the image (optional, defaults to the file name)"),
+		),
+		mcp.WithString("name",
+			mcp.Description("The resource name of the image (optional, generated by default)"),
+		),
+		mcp.WithString("checksum",
+			mcp.Description("The SHA-512 checksum to verify the upload against (optional)"),
+		),
+		mcp.WithString("storage_class",
+			mcp.Description("The storage class whose parameters the image uses (optional, defaults to the default storage class)"),
+		),
+		mcp.WithString("description",
+			mcp.Description("A description of the image (optional)"),
+		),
+	)
+	s.mcpServer.AddTool(uploadImageTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		filePath, ok := req.Params.Arguments["file_path"].(string)
+		if !ok || filePath == "" {
+			return mcp.NewToolResultError("File path is required"), nil
+		}
+
+		image, err := s.resourceHandler.UploadImage(ctx, getImageCreateOptions(req, namespace), filePath)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to upload image %s to namespace %s: %v", filePath, namespace, err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeImage]
+		formatted := s.resourceHandler.FormatResource(image, gvr)
+		return mcp.NewToolResultText(fmt.Sprintf("Image %s in namespace %s uploaded successfully\n\n%s", image.GetName(), namespace, formatted)), nil
+	})
+
+	// Export volume to image tool
+	exportVolumeToImageTool := mcp.NewTool(
+		"export_volume_to_image",
+		mcp.WithDescription("Create an Image from the contents of a volume, e.g. a configured VM disk, and wait for the export to complete"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace to create the image in"),
+		),
+		mcp.WithString("volume",
+			mcp.Required(),
+			mcp.Description("The name of the volume (PVC) to export"),
+		),
+		mcp.WithString("volume_namespace",
+			mcp.Description("The namespace of the volume (optional, defaults to the image namespace)"),
+		),
+		mcp.WithString("display_name",
+			mcp.Description("The display name of the image (optional, defaults to the volume name)"),
+		),
+		mcp.WithString("name",
+			mcp.Description("The resource name of the image (optional, generated by default)"),
+		),
+		mcp.WithString("storage_class",
+			mcp.Description("The storage class whose parameters the image uses (optional, defaults to the storage class of the volume)"),
+		),
+		mcp.WithString("description",
+			mcp.Description("A description of the image (optional)"),
+		),
+		mcp.WithNumber("timeout",
+			mcp.Description(fmt.Sprintf("Seconds to wait for the export to complete (optional, defaults to %d, maximum %d)", int(defaultWaitTimeout.Seconds()), int(maxWaitTimeout.Seconds()))),
+		),
+	)
+	s.mcpServer.AddTool(exportVolumeToImageTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		volume, ok := req.Params.Arguments["volume"].(string)
+		if !ok || volume == "" {
+			return mcp.NewToolResultError("Volume name is required"), nil
+		}
+
+		volumeNamespace, _ := req.Params.Arguments["volume_namespace"].(string)
+
+		result, err := s.resourceHandler.ExportVolumeToImage(ctx, getImageCreateOptions(req, namespace), volumeNamespace, volume, getTimeoutArgument(req))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to export volume %s to an image: %v", volume, err)), nil
+		}
+
+		message := fmt.Sprintf("Volume %s exported to image %s in namespace %s successfully", volume, result.Image.GetName(), namespace)
+		if !result.Completed {
+			message = fmt.Sprintf("Export of volume %s to image %s in namespace %s is still in progress; use get_image to follow it", volume, result.Image.GetName(), namespace)
+		}
+
+		progress := make([]string, 0, len(result.Progress))
+		for _, p := range result.Progress {
+			progress = append(progress, fmt.Sprintf("%d%% (%s)", p.Percent, p.Elapsed))
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeImage]
+		formatted := s.resourceHandler.FormatResource(result.Image, gvr)
+		return mcp.NewToolResultText(fmt.Sprintf("%s\nProgress: %s\n\n%s", message, strings.Join(progress, " -> "), formatted)), nil
+	})
+
+	// Delete image tool
+	deleteImageTool := mcp.NewTool(
+		"delete_image",
+		mcp.WithDescription("Delete an Image that no volume was provisioned from"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the image"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the image"),
+		),
+	)
+	s.mcpServer.AddTool(deleteImageTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Image name is required"), nil
+		}
+
+		if err := s.resourceHandler.DeleteImage(ctx, namespace, name); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete image %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Image %s in namespace %s deleted successfully", name, namespace)), nil
+	})
+}
+
+// registerHarvesterVolumeTools registers Harvester Volume-related tools.
+func (s *HarvesterMCPServer) registerHarvesterVolumeTools() {
+	// List volumes tool
+	listVolumesTool := mcp.NewTool(
+		"list_volumes",
+		mcp.WithDescription("List Volumes (PVCs) in the Harvester cluster with their Longhorn state and the VMs using them"),
+		mcp.WithString("namespace",
+			mcp.Description("The namespace to list volumes from (optional, defaults to all namespaces)"),
+		),
+	)
+	s.mcpServer.AddTool(listVolumesTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, _ := req.Params.Arguments["namespace"].(string)
+
+		volumes, err := s.resourceHandler.ListVolumes(ctx, namespace)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list volumes: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(kubernetes.FormatVolumeDetailsList(volumes)), nil
+	})
+
+	// Get volume tool
+	getVolumeTool := mcp.NewTool(
+		"get_volume",
+		mcp.WithDescription("Get Volume (PVC) details including its Longhorn robustness, replicas, attached node, actual size and the VMs using it"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the volume"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the volume"),
+		),
+	)
+	s.mcpServer.AddTool(getVolumeTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Volume name is required"), nil
+		}
+
+		resource, err := s.resourceHandler.GetVolume(ctx, namespace, name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get volume %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		formatted := kubernetes.FormatVolumeDetails(resource)
+		return mcp.NewToolResultText(formatted), nil
+	})
+
+	// Diagnose volume tool
+	diagnoseVolumeTool := mcp.NewTool(
+		"diagnose_volume",
+		mcp.WithDescription("Diagnose the Longhorn health of a Volume (PVC): replica nodes and disks, rebuild progress, degraded or faulted state and scheduling failures, with a plain-language summary"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the volume"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the volume"),
+		),
+	)
+	s.mcpServer.AddTool(diagnoseVolumeTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Volume name is required"), nil
+		}
+
+		diagnosis, err := s.resourceHandler.DiagnoseVolume(ctx, namespace, name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to diagnose volume %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		return mcp.NewToolResultText(kubernetes.FormatVolumeDiagnosis(diagnosis)), nil
+	})
+
+	// Create volume tool
+	createVolumeTool := mcp.NewTool(
+		"create_volume",
+		mcp.WithDescription("Create a Volume (PVC), either blank or populated from an image"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace to create the volume in"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the volume"),
+		),
+		mcp.WithString("size",
+			mcp.Required(),
+			mcp.Description("The size of the volume, e.g. 10Gi"),
+		),
+		mcp.WithString("image",
+			mcp.Description("The image to populate the volume from, by name, display name or namespace/name (optional, defaults to a blank volume)"),
+		),
+		mcp.WithString("storage_class",
+			mcp.Description("The storage class of the volume (optional, defaults to the image's storage class or the cluster default)"),
+		),
+		mcp.WithString("access_mode",
+			mcp.Description("The access mode of the volume (optional, defaults to ReadWriteMany)"),
+			mcp.Enum("ReadWriteMany", "ReadWriteOnce", "ReadOnlyMany"),
+		),
+		mcp.WithString("volume_mode",
+			mcp.Description("The volume mode of the volume (optional, defaults to Block)"),
+			mcp.Enum("Block", "Filesystem"),
+		),
+	)
+	s.mcpServer.AddTool(createVolumeTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Volume name is required"), nil
+		}
+
+		size, ok := req.Params.Arguments["size"].(string)
+		if !ok || size == "" {
+			return mcp.NewToolResultError("Volume size is required"), nil
+		}
+
+		image, _ := req.Params.Arguments["image"].(string)
+		storageClass, _ := req.Params.Arguments["storage_class"].(string)
+		accessMode, _ := req.Params.Arguments["access_mode"].(string)
+		volumeMode, _ := req.Params.Arguments["volume_mode"].(string)
+
+		_, err := s.resourceHandler.CreateVolume(ctx, kubernetes.VolumeCreateOptions{
+			Name:         name,
+			Namespace:    namespace,
+			Size:         size,
+			StorageClass: storageClass,
+			AccessMode:   accessMode,
+			VolumeMode:   volumeMode,
+			Image:        image,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to create volume %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		resource, err := s.resourceHandler.GetVolume(ctx, namespace, name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Volume %s in namespace %s created, but failed to get it: %v", name, namespace, err)), nil
+		}
+
+		formatted := kubernetes.FormatVolumeDetails(resource)
+		return mcp.NewToolResultText(fmt.Sprintf("Volume %s in namespace %s created successfully\n\n%s", name, namespace, formatted)), nil
+	})
+
+	// Expand volume tool
+	expandVolumeTool := mcp.NewTool(
+		"expand_volume",
+		mcp.WithDescription("Expand a Volume (PVC) to a larger size; its storage class must allow volume expansion"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the volume"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the volume"),
+		),
+		mcp.WithString("size",
+			mcp.Required(),
+			mcp.Description("The new size of the volume, e.g. 20Gi"),
+		),
+	)
+	s.mcpServer.AddTool(expandVolumeTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Volume name is required"), nil
+		}
+
+		size, ok := req.Params.Arguments["size"].(string)
+		if !ok || size == "" {
+			return mcp.NewToolResultError("Volume size is required"), nil
+		}
+
+		resource, err := s.resourceHandler.ExpandVolume(ctx, namespace, name, size)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to expand volume %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		formatted := kubernetes.FormatVolumeDetails(resource)
+		return mcp.NewToolResultText(fmt.Sprintf("Volume %s in namespace %s expansion to %s requested successfully\n\n%s", name, namespace, size, formatted)), nil
+	})
+
+	// Clone volume tool
+	cloneVolumeTool := mcp.NewTool(
+		"clone_volume",
+		mcp.WithDescription("Clone a Volume (PVC) into a new volume in the same namespace"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the volume"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the volume to clone"),
+		),
+		mcp.WithString("target_name",
+			mcp.Required(),
+			mcp.Description("The name of the new volume"),
+		),
+	)
+	s.mcpServer.AddTool(cloneVolumeTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Volume name is required"), nil
+		}
+
+		targetName, ok := req.Params.Arguments["target_name"].(string)
+		if !ok || targetName == "" {
+			return mcp.NewToolResultError("Target volume name is required"), nil
+		}
+
+		if _, err := s.resourceHandler.CloneVolume(ctx, namespace, name, targetName); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to clone volume %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		resource, err := s.resourceHandler.GetVolume(ctx, namespace, targetName)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Volume %s in namespace %s cloned to %s, but failed to get it: %v", name, namespace, targetName, err)), nil
+		}
+
+		formatted := kubernetes.FormatVolumeDetails(resource)
+		return mcp.NewToolResultText(fmt.Sprintf("Volume %s in namespace %s cloned to %s successfully\n\n%s", name, namespace, targetName, formatted)), nil
+	})
+
+	// Delete volume tool
+	deleteVolumeTool := mcp.NewTool(
+		"delete_volume",
+		mcp.WithDescription("Delete a Volume (PVC); volumes used by a VM are only deleted when forced"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the volume"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the volume"),
+		),
+		mcp.WithBoolean("force",
+			mcp.Description("Delete the volume even if a VM still uses it (optional, defaults to false)"),
+		),
+	)
+	s.mcpServer.AddTool(deleteVolumeTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Volume name is required"), nil
+		}
+
+		force, _ := req.Params.Arguments["force"].(bool)
+
+		vms, err := s.resourceHandler.DeleteVolume(ctx, namespace, name, force)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete volume %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		message := fmt.Sprintf("Volume %s in namespace %s deleted successfully", name, namespace)
+		if len(vms) > 0 {
+			message += fmt.Sprintf("\n\nWarning: VMs %s still reference the volume; it stays terminating while a running VM uses it, and the VMs cannot start without it", strings.Join(vms, ", "))
+		}
+		return mcp.NewToolResultText(message), nil
+	})
+}
+
+// registerHarvesterVolumeSnapshotTools registers VolumeSnapshot-related tools.
+func (s *HarvesterMCPServer) registerHarvesterVolumeSnapshotTools() {
+	// Create volume snapshot tool
+	createVolumeSnapshotTool := mcp.NewTool(
+		"create_volume_snapshot",
+		mcp.WithDescription("Create a snapshot of a single Volume (PVC) and wait until it is ready to use"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the volume"),
+		),
+		mcp.WithString("volume",
+			mcp.Required(),
+			mcp.Description("The name of the volume to snapshot"),
+		),
+		mcp.WithString("name",
+			mcp.Description("The name of the snapshot (optional, generated from the volume name by default)"),
+		),
+		mcp.WithNumber("timeout",
+			mcp.Description(fmt.Sprintf("Seconds to wait for the snapshot to become ready (optional, defaults to %d, maximum %d)", int(defaultWaitTimeout.Seconds()), int(maxWaitTimeout.Seconds()))),
+		),
+	)
+	s.mcpServer.AddTool(createVolumeSnapshotTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		volume, ok := req.Params.Arguments["volume"].(string)
+		if !ok || volume == "" {
+			return mcp.NewToolResultError("Volume name is required"), nil
+		}
+
+		name, _ := req.Params.Arguments["name"].(string)
+
+		snapshot, err := s.resourceHandler.CreateVolumeSnapshot(ctx, namespace, volume, name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to create snapshot of volume %s in namespace %s: %v", volume, namespace, err)), nil
+		}
+
+		if _, err := s.resourceHandler.WaitForVolumeSnapshot(ctx, namespace, snapshot.GetName(), getTimeoutArgument(req)); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Snapshot %s of volume %s in namespace %s was created, but: %v", snapshot.GetName(), volume, namespace, err)), nil
+		}
+
+		details, err := s.resourceHandler.GetVolumeSnapshot(ctx, namespace, snapshot.GetName())
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Snapshot of volume %s in namespace %s is ready, but failed to get it: %v", volume, namespace, err)), nil
+		}
+
+		formatted := kubernetes.FormatVolumeSnapshotDetails(details)
+		return mcp.NewToolResultText(fmt.Sprintf("Snapshot %s of volume %s in namespace %s created successfully\n\n%s", snapshot.GetName(), volume, namespace, formatted)), nil
+	})
+
+	// List volume snapshots tool
+	listVolumeSnapshotsTool := mcp.NewTool(
+		"list_volume_snapshots",
+		mcp.WithDescription("List Volume snapshots with their readiness and restore size"),
+		mcp.WithString("namespace",
+			mcp.Description("The namespace to list volume snapshots from (optional, defaults to all namespaces)"),
+		),
+		mcp.WithString("volume",
+			mcp.Description("Only list snapshots of this volume (optional)"),
+		),
+	)
+	s.mcpServer.AddTool(listVolumeSnapshotsTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, _ := req.Params.Arguments["namespace"].(string)
+		volume, _ := req.Params.Arguments["volume"].(string)
+
+		list, err := s.resourceHandler.ListVolumeSnapshots(ctx, namespace, volume)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list volume snapshots: %v", err)), nil
+		}
+		if len(list.Items) == 0 {
+			return mcp.NewToolResultText("No volume snapshots found in the specified namespace(s)."), nil
+		}
+
+		// Format the list using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeVolumeSnapshots]
+		formatted := s.resourceHandler.FormatResourceList(list, gvr)
+		return mcp.NewToolResultText(formatted), nil
+	})
+
+	// Get volume snapshot tool
+	getVolumeSnapshotTool := mcp.NewTool(
+		"get_volume_snapshot",
+		mcp.WithDescription("Get Volume snapshot details including its bound snapshot content"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the volume snapshot"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the volume snapshot"),
+		),
+	)
+	s.mcpServer.AddTool(getVolumeSnapshotTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Volume snapshot name is required"), nil
+		}
+
+		snapshot, err := s.resourceHandler.GetVolumeSnapshot(ctx, namespace, name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get volume snapshot %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		return mcp.NewToolResultText(kubernetes.FormatVolumeSnapshotDetails(snapshot)), nil
+	})
+
+	// Restore volume snapshot tool
+	restoreVolumeSnapshotTool := mcp.NewTool(
+		"restore_volume_snapshot",
+		mcp.WithDescription("Restore a Volume snapshot into a new Volume (PVC) in the same namespace"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the volume snapshot"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the volume snapshot to restore"),
+		),
+		mcp.WithString("target_name",
+			mcp.Required(),
+			mcp.Description("The name of the new volume"),
+		),
+		mcp.WithString("storage_class",
+			mcp.Description("The storage class of the new volume (optional, defaults to that of the snapshotted volume)"),
+		),
+		mcp.WithString("access_mode",
+			mcp.Description("The access mode of the new volume (optional, defaults to that of the snapshotted volume)"),
+			mcp.Enum("ReadWriteMany", "ReadWriteOnce", "ReadOnlyMany"),
+		),
+		mcp.WithString("volume_mode",
+			mcp.Description("The volume mode of the new volume (optional, defaults to that of the snapshotted volume)"),
+			mcp.Enum("Block", "Filesystem"),
+		),
+	)
+	s.mcpServer.AddTool(restoreVolumeSnapshotTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Volume snapshot name is required"), nil
+		}
+
+		targetName, ok := req.Params.Arguments["target_name"].(string)
+		if !ok || targetName == "" {
+			return mcp.NewToolResultError("Target volume name is required"), nil
+		}
+
+		storageClass, _ := req.Params.Arguments["storage_class"].(string)
+		accessMode, _ := req.Params.Arguments["access_mode"].(string)
+		volumeMode, _ := req.Params.Arguments["volume_mode"].(string)
+
+		_, err := s.resourceHandler.RestoreVolumeSnapshot(ctx, kubernetes.VolumeSnapshotRestoreOptions{
+			Namespace:    namespace,
+			SnapshotName: name,
+			Name:         targetName,
+			StorageClass: storageClass,
+			AccessMode:   accessMode,
+			VolumeMode:   volumeMode,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to restore volume snapshot %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		resource, err := s.resourceHandler.GetVolume(ctx, namespace, targetName)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Volume snapshot %s in namespace %s restored to %s, but failed to get it: %v", name, namespace, targetName, err)), nil
+		}
+
+		formatted := kubernetes.FormatVolumeDetails(resource)
+		return mcp.NewToolResultText(fmt.Sprintf("Volume snapshot %s in namespace %s restored to volume %s successfully\n\n%s", name, namespace, targetName, formatted)), nil
+	})
+
+	// Delete volume snapshot tool
+	deleteVolumeSnapshotTool := mcp.NewTool(
+		"delete_volume_snapshot",
+		mcp.WithDescription("Delete a Volume snapshot that is not part of a VM snapshot or backup"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the volume snapshot"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the volume snapshot to delete"),
+		),
+	)
+	s.mcpServer.AddTool(deleteVolumeSnapshotTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Volume snapshot name is required"), nil
+		}
+
+		if err := s.resourceHandler.DeleteVolumeSnapshot(ctx, namespace, name); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete volume snapshot %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Volume snapshot %s in namespace %s deleted successfully", name, namespace)), nil
+	})
+}
+
+// registerStorageClassTools registers StorageClass-related tools.
+func (s *HarvesterMCPServer) registerStorageClassTools() {
+	// List storage classes tool
+	listStorageClassesTool := mcp.NewTool(
+		"list_storage_classes",
+		mcp.WithDescription("List Storage Classes with their Longhorn replica, selector and migration settings, marking the default class"),
+	)
+	s.mcpServer.AddTool(listStorageClassesTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		list, err := s.resourceHandler.ListStorageClasses(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list storage classes: %v", err)), nil
+		}
+
+		// Format the list using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeStorageClasses]
+		formatted := s.resourceHandler.FormatResourceList(list, gvr)
+		return mcp.NewToolResultText(formatted), nil
+	})
+
+	// Get storage class tool
+	getStorageClassTool := mcp.NewTool(
+		"get_storage_class",
+		mcp.WithDescription("Get Storage Class details including its Longhorn parameters"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the storage class"),
+		),
+	)
+	s.mcpServer.AddTool(getStorageClassTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Storage class name is required"), nil
+		}
+
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeStorageClass]
+		resource, err := s.resourceHandler.GetResource(ctx, gvr, "", name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get storage class %s: %v", name, err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		formatted := s.resourceHandler.FormatResource(resource, gvr)
+		return mcp.NewToolResultText(formatted), nil
+	})
+
+	// Create storage class tool
+	createStorageClassTool := mcp.NewTool(
+		"create_storage_class",
+		mcp.WithDescription("Create a Longhorn Storage Class for Harvester volumes"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the storage class"),
+		),
+		mcp.WithNumber("replicas",
+			mcp.Description("The number of Longhorn replicas of each volume (optional, defaults to 3)"),
+		),
+		mcp.WithNumber("stale_replica_timeout",
+			mcp.Description("Minutes before a failed replica is cleaned up (optional, defaults to 30)"),
+		),
+		mcp.WithString("disk_selector",
+			mcp.Description("Comma-separated Longhorn disk tags replicas must be placed on (optional)"),
+		),
+		mcp.WithString("node_selector",
+			mcp.Description("Comma-separated Longhorn node tags replicas must be placed on (optional)"),
+		),
+		mcp.WithBoolean("migratable",
+			mcp.Description("Allow live migration of VMs using volumes of this class (optional, defaults to true)"),
+		),
+		mcp.WithString("data_locality",
+			mcp.Description("Whether Longhorn keeps a replica on the node using the volume (optional, defaults to disabled)"),
+			mcp.Enum("disabled", "best-effort", "strict-local"),
+		),
+		mcp.WithString("reclaim_policy",
+			mcp.Description("What happens to volumes when their claim is deleted (optional, defaults to Delete)"),
+			mcp.Enum("Delete", "Retain"),
+		),
+		mcp.WithString("volume_binding_mode",
+			mcp.Description("When volumes are provisioned (optional, defaults to Immediate)"),
+			mcp.Enum("Immediate", "WaitForFirstConsumer"),
+		),
+		mcp.WithBoolean("allow_volume_expansion",
+			mcp.Description("Allow volumes of this class to be expanded (optional, defaults to true)"),
+		),
+		mcp.WithBoolean("set_default",
+			mcp.Description("Make this the default storage class, replacing the current default (optional, defaults to false)"),
+		),
+	)
+	s.mcpServer.AddTool(createStorageClassTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Storage class name is required"), nil
+		}
+
+		replicas, _ := req.Params.Arguments["replicas"].(float64)
+		staleReplicaTimeout, _ := req.Params.Arguments["stale_replica_timeout"].(float64)
+		dataLocality, _ := req.Params.Arguments["data_locality"].(string)
+		reclaimPolicy, _ := req.Params.Arguments["reclaim_policy"].(string)
+		volumeBindingMode, _ := req.Params.Arguments["volume_binding_mode"].(string)
+		setDefault, _ := req.Params.Arguments["set_default"].(bool)
+		migratable, ok := req.Params.Arguments["migratable"].(bool)
+		if !ok {
+			migratable = true
+		}
+		allowVolumeExpansion, ok := req.Params.Arguments["allow_volume_expansion"].(bool)
+		if !ok {
+			allowVolumeExpansion = true
+		}
+
+		storageClass, err := s.resourceHandler.CreateStorageClass(ctx, kubernetes.StorageClassCreateOptions{
+			Name:                 name,
+			NumberOfReplicas:     int(replicas),
+			StaleReplicaTimeout:  int(staleReplicaTimeout),
+			DiskSelector:         getListArgument(req, "disk_selector"),
+			NodeSelector:         getListArgument(req, "node_selector"),
+			Migratable:           migratable,
+			DataLocality:         dataLocality,
+			ReclaimPolicy:        reclaimPolicy,
+			VolumeBindingMode:    volumeBindingMode,
+			AllowVolumeExpansion: allowVolumeExpansion,
+			Default:              setDefault,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to create storage class %s: %v", name, err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeStorageClass]
+		formatted := s.resourceHandler.FormatResource(storageClass, gvr)
+		return mcp.NewToolResultText(fmt.Sprintf("Storage class %s created successfully\n\n%s", name, formatted)), nil
+	})
+}
+
+// registerHarvesterNetworkTools registers Harvester Network-related tools.
+func (s *HarvesterMCPServer) registerHarvesterNetworkTools() {
+	// List networks tool
+	listNetworksTool := mcp.NewTool(
+		"list_networks",
+		mcp.WithDescription("List VM Networks (NetworkAttachmentDefinitions) with their cluster network, VLAN ID, bridge and the VMs using them"),
+		mcp.WithString("namespace",
+			mcp.Description("The namespace to list networks from (optional, defaults to all namespaces)"),
+		),
+	)
+	s.mcpServer.AddTool(listNetworksTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, _ := req.Params.Arguments["namespace"].(string)
+
+		networks, err := s.resourceHandler.ListNetworks(ctx, namespace)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list networks: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(kubernetes.FormatNetworkDetailsList(networks)), nil
+	})
+
+	// Get network tool
+	getNetworkTool := mcp.NewTool(
+		"get_network",
+		mcp.WithDescription("Get VM Network details including its CNI config (bridge, VLAN ID, IPAM), route and the VMs using it"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the network"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the network"),
+		),
+	)
+	s.mcpServer.AddTool(getNetworkTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Network name is required"), nil
+		}
+
+		resource, err := s.resourceHandler.GetNetwork(ctx, namespace, name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get network %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		formatted := kubernetes.FormatNetworkDetails(resource)
+		return mcp.NewToolResultText(formatted), nil
+	})
+
+	// Create VM network tool
+	createVMNetworkTool := mcp.NewTool(
+		"create_vm_network",
+		mcp.WithDescription("Create a VM Network (L2 VLAN or untagged) on a cluster network, the same way the Harvester UI does"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace to create the network in"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the network"),
+		),
+		mcp.WithString("cluster_network",
+			mcp.Required(),
+			mcp.Description("The cluster network to attach the network to, e.g. mgmt"),
+		),
+		mcp.WithString("type",
+			mcp.Description("The network type (optional, defaults to L2VlanNetwork)"),
+			mcp.Enum(kubernetes.VMNetworkTypeL2VLAN, kubernetes.VMNetworkTypeUntagged),
+		),
+		mcp.WithNumber("vlan_id",
+			mcp.Description("The VLAN ID between 1 and 4094 (required for L2VlanNetwork)"),
+		),
+		mcp.WithString("route_mode",
+			mcp.Description("How the route of a VLAN network is obtained (optional, defaults to manual when a CIDR is given, auto otherwise)"),
+			mcp.Enum(kubernetes.NetworkRouteModeAuto, kubernetes.NetworkRouteModeManual),
+		),
+		mcp.WithString("cidr",
+			mcp.Description("The CIDR of a VLAN network in manual route mode, e.g. 172.16.0.0/24 (optional)"),
+		),
+		mcp.WithString("gateway",
+			mcp.Description("The gateway of a VLAN network in manual route mode, e.g. 172.16.0.1 (optional)"),
+		),
+	)
+	s.mcpServer.AddTool(createVMNetworkTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Network name is required"), nil
+		}
+
+		clusterNetwork, ok := req.Params.Arguments["cluster_network"].(string)
+		if !ok || clusterNetwork == "" {
+			return mcp.NewToolResultError("Cluster network is required"), nil
+		}
+
+		networkType, _ := req.Params.Arguments["type"].(string)
+		if networkType == "" {
+			networkType = kubernetes.VMNetworkTypeL2VLAN
+		}
+		vlanID, _ := req.Params.Arguments["vlan_id"].(float64)
+		routeMode, _ := req.Params.Arguments["route_mode"].(string)
+		cidr, _ := req.Params.Arguments["cidr"].(string)
+		gateway, _ := req.Params.Arguments["gateway"].(string)
+
+		_, err := s.resourceHandler.CreateVMNetwork(ctx, kubernetes.VMNetworkCreateOptions{
+			Namespace:      namespace,
+			Name:           name,
+			ClusterNetwork: clusterNetwork,
+			Type:           networkType,
+			VLANID:         int(vlanID),
+			RouteMode:      routeMode,
+			CIDR:           cidr,
+			Gateway:        gateway,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to create network %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		resource, err := s.resourceHandler.GetNetwork(ctx, namespace, name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Network %s in namespace %s created, but failed to get it: %v", name, namespace, err)), nil
+		}
+
+		formatted := kubernetes.FormatNetworkDetails(resource)
+		return mcp.NewToolResultText(fmt.Sprintf("Network %s in namespace %s created successfully\n\n%s", name, namespace, formatted)), nil
+	})
+
+	// Delete VM network tool
+	deleteVMNetworkTool := mcp.NewTool(
+		"delete_vm_network",
+		mcp.WithDescription("Delete a VM Network that no VM is attached to"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the network"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the network to delete"),
+		),
+	)
+	s.mcpServer.AddTool(deleteVMNetworkTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Network name is required"), nil
+		}
+
+		if err := s.resourceHandler.DeleteVMNetwork(ctx, namespace, name); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete network %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Network %s in namespace %s deleted successfully", name, namespace)), nil
+	})
+}
+
+// registerHarvesterClusterNetworkTools registers tools for cluster networks and their VLAN uplinks.
+func (s *HarvesterMCPServer) registerHarvesterClusterNetworkTools() {
+	// List cluster networks tool
+	listClusterNetworksTool := mcp.NewTool(
+		"list_cluster_networks",
+		mcp.WithDescription("List Cluster Networks with the uplink NICs, bond mode and MTU of each node group, highlighting nodes whose VLAN setup is not ready"),
+	)
+	s.mcpServer.AddTool(listClusterNetworksTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		clusterNetworks, err := s.resourceHandler.ListClusterNetworks(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list cluster networks: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(kubernetes.FormatClusterNetworkDetailsList(clusterNetworks)), nil
+	})
+
+	// Get cluster network tool
+	getClusterNetworkTool := mcp.NewTool(
+		"get_cluster_network",
+		mcp.WithDescription("Get Cluster Network details including its VLAN configs (uplink NICs, bond options, MTU, node selector) and the VLAN status of every participating node"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the cluster network, e.g. mgmt"),
+		),
+	)
+	s.mcpServer.AddTool(getClusterNetworkTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Cluster network name is required"), nil
+		}
+
+		clusterNetwork, err := s.resourceHandler.GetClusterNetwork(ctx, name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get cluster network %s: %v", name, err)), nil
+		}
+
+		formatted := kubernetes.FormatClusterNetworkDetails(clusterNetwork)
+		return mcp.NewToolResultText(formatted), nil
+	})
+
+	// List VLAN configs tool
+	listVlanConfigsTool := mcp.NewTool(
+		"list_vlan_configs",
+		mcp.WithDescription("List VLAN Configs, which define the uplink NICs, bond mode and MTU a group of nodes uses for a cluster network"),
+		mcp.WithString("cluster_network",
+			mcp.Description("Only list the VLAN configs of this cluster network (optional)"),
+		),
+	)
+	s.mcpServer.AddTool(listVlanConfigsTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		clusterNetwork, _ := req.Params.Arguments["cluster_network"].(string)
+
+		list, err := s.resourceHandler.ListVlanConfigs(ctx, clusterNetwork)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list VLAN configs: %v", err)), nil
+		}
+
+		// Format the list using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeVlanConfigs]
+		formatted := s.resourceHandler.FormatResourceList(list, gvr)
+		return mcp.NewToolResultText(formatted), nil
+	})
+
+	// List VLAN statuses tool
+	listVlanStatusesTool := mcp.NewTool(
+		"list_vlan_statuses",
+		mcp.WithDescription("List the per-node VLAN Status of cluster networks, showing whether each node's uplink is ready"),
+		mcp.WithString("cluster_network",
+			mcp.Description("Only list the VLAN statuses of this cluster network (optional)"),
+		),
+		mcp.WithBoolean("not_ready_only",
+			mcp.Description("Only list nodes whose VLAN setup is not ready (optional, defaults to false)"),
+		),
+	)
+	s.mcpServer.AddTool(listVlanStatusesTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		clusterNetwork, _ := req.Params.Arguments["cluster_network"].(string)
+		notReadyOnly, _ := req.Params.Arguments["not_ready_only"].(bool)
+
+		list, err := s.resourceHandler.ListVlanStatuses(ctx, clusterNetwork, notReadyOnly)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list VLAN statuses: %v", err)), nil
+		}
+
+		// Format the list using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeVlanStatuses]
+		formatted := s.resourceHandler.FormatResourceList(list, gvr)
+		return mcp.NewToolResultText(formatted), nil
+	})
+}
+
+// registerHarvesterLoadBalancerTools registers tools for Harvester load balancers and their IP pools.
+func (s *HarvesterMCPServer) registerHarvesterLoadBalancerTools() {
+	// List load balancers tool
+	listLoadBalancersTool := mcp.NewTool(
+		"list_load_balancers",
+		mcp.WithDescription("List Load Balancers with their allocated address, listeners and the VMs behind them"),
+		mcp.WithString("namespace",
+			mcp.Description("The namespace to list load balancers from (optional, defaults to all namespaces)"),
+		),
+	)
+	s.mcpServer.AddTool(listLoadBalancersTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, _ := req.Params.Arguments["namespace"].(string)
+
+		lbs, err := s.resourceHandler.ListLoadBalancers(ctx, namespace)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list load balancers: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(kubernetes.FormatLoadBalancerDetailsList(lbs)), nil
+	})
+
+	// Get load balancer tool
+	getLoadBalancerTool := mcp.NewTool(
+		"get_load_balancer",
+		mcp.WithDescription("Get Load Balancer details including its allocated IP, listeners, backend selector and the VMs it forwards to"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace of the load balancer"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the load balancer"),
+		),
+	)
+	s.mcpServer.AddTool(getLoadBalancerTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Load balancer name is required"), nil
+		}
+
+		resource, err := s.resourceHandler.GetLoadBalancer(ctx, namespace, name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get load balancer %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		formatted := kubernetes.FormatLoadBalancerDetails(resource)
+		return mcp.NewToolResultText(formatted), nil
+	})
+
+	// Create load balancer tool
+	createLoadBalancerTool := mcp.NewTool(
+		"create_load_balancer",
+		mcp.WithDescription("Create a Load Balancer that exposes ports of the VMs matching a label selector"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The namespace to create the load balancer in; only VMs in this namespace are selected"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the load balancer"),
+		),
+		mcp.WithString("listeners",
+			mcp.Required(),
+			mcp.Description("Comma-separated listeners as name:port:backendPort[:protocol], e.g. http:80:8080,dns:53:53:UDP (protocol defaults to TCP)"),
+		),
+		mcp.WithString("backend_selector",
+			mcp.Required(),
+			mcp.Description("Comma-separated VM label terms as key=value; repeat a key to allow several values, e.g. app=web"),
+		),
+		mcp.WithString("ipam",
+			mcp.Description("How the load balancer address is allocated (optional, defaults to pool)"),
+			mcp.Enum(kubernetes.LoadBalancerIPAMPool, kubernetes.LoadBalancerIPAMDHCP),
+		),
+		mcp.WithString("ip_pool",
+			mcp.Description("The IP pool to allocate the address from with pool IPAM (optional, defaults to the pool whose selector matches)"),
+		),
+		mcp.WithString("description",
+			mcp.Description("A description of the load balancer (optional)"),
+		),
+	)
+	s.mcpServer.AddTool(createLoadBalancerTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		namespace, ok := req.Params.Arguments["namespace"].(string)
+		if !ok || namespace == "" {
+			return mcp.NewToolResultError("Namespace is required"), nil
+		}
+
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Load balancer name is required"), nil
+		}
+
+		var listeners []kubernetes.LoadBalancerListener
+		for _, value := range getListArgument(req, "listeners") {
+			listener, err := kubernetes.ParseLoadBalancerListener(value)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			listeners = append(listeners, listener)
+		}
+		if len(listeners) == 0 {
+			return mcp.NewToolResultError("At least one listener is required"), nil
+		}
+
+		selector, err := kubernetes.ParseLoadBalancerBackendSelector(getListArgument(req, "backend_selector"))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if len(selector) == 0 {
+			return mcp.NewToolResultError("Backend selector is required"), nil
+		}
+
+		ipam, _ := req.Params.Arguments["ipam"].(string)
+		ipPool, _ := req.Params.Arguments["ip_pool"].(string)
+		description, _ := req.Params.Arguments["description"].(string)
+
+		_, err = s.resourceHandler.CreateLoadBalancer(ctx, kubernetes.LoadBalancerCreateOptions{
+			Namespace:       namespace,
+			Name:            name,
+			Description:     description,
+			IPAM:            ipam,
+			IPPool:          ipPool,
+			Listeners:       listeners,
+			BackendSelector: selector,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to create load balancer %s in namespace %s: %v", name, namespace, err)), nil
+		}
+
+		resource, err := s.resourceHandler.GetLoadBalancer(ctx, namespace, name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Load balancer %s in namespace %s created, but failed to get it: %v", name, namespace, err)), nil
+		}
+
+		formatted := kubernetes.FormatLoadBalancerDetails(resource)
+		return mcp.NewToolResultText(fmt.Sprintf("Load balancer %s in namespace %s created successfully; its address is allocated asynchronously\n\n%s", name, namespace, formatted)), nil
+	})
+
+	// List IP pools tool
+	listIPPoolsTool := mcp.NewTool(
+		"list_ip_pools",
+		mcp.WithDescription("List load balancer IP Pools with their ranges and used/free address counts"),
+	)
+	s.mcpServer.AddTool(listIPPoolsTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		list, err := s.resourceHandler.ListIPPools(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list IP pools: %v", err)), nil
+		}
+
+		// Format the list using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeIPPools]
+		formatted := s.resourceHandler.FormatResourceList(list, gvr)
+		return mcp.NewToolResultText(formatted), nil
+	})
+
+	// Get IP pool tool
+	getIPPoolTool := mcp.NewTool(
+		"get_ip_pool",
+		mcp.WithDescription("Get IP Pool details including its ranges, selector and which load balancer holds each allocated address"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the IP pool"),
+		),
+	)
+	s.mcpServer.AddTool(getIPPoolTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("IP pool name is required"), nil
+		}
+
+		// Use the unified resource handler
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeIPPool]
+		resource, err := s.resourceHandler.GetResource(ctx, gvr, "", name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get IP pool %s: %v", name, err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		formatted := s.resourceHandler.FormatResource(resource, gvr)
+		return mcp.NewToolResultText(formatted), nil
+	})
+}
+
+// registerHarvesterNodeMaintenanceTools registers tools for Harvester node maintenance mode.
+func (s *HarvesterMCPServer) registerHarvesterNodeMaintenanceTools() {
+	// Enable maintenance mode tool
+	enableMaintenanceModeTool := mcp.NewTool(
+		"enable_maintenance_mode",
+		mcp.WithDescription("Put a node into maintenance mode through Harvester, which cordons it and live migrates its VMs to other nodes, reporting which VMs were migrated and which are blocking"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the node"),
+		),
+		mcp.WithBoolean("force",
+			mcp.Description("Shut down VMs that cannot be live migrated instead of refusing (optional, defaults to false)"),
+		),
+		mcp.WithNumber("timeout",
+			mcp.Description(fmt.Sprintf("Seconds to wait for the VMs to leave the node (optional, defaults to %d, maximum %d)", int(defaultWaitTimeout.Seconds()), int(maxWaitTimeout.Seconds()))),
+		),
+	)
+	s.mcpServer.AddTool(enableMaintenanceModeTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Node name is required"), nil
+		}
+
+		force, _ := req.Params.Arguments["force"].(bool)
+
+		result, err := s.resourceHandler.EnableNodeMaintenanceMode(ctx, name, force, getTimeoutArgument(req))
+		if err != nil {
+			if result != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to enable maintenance mode on node %s: %v\n\n%s", name, err, kubernetes.FormatNodeMaintenanceResult(result))), nil
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to enable maintenance mode on node %s: %v", name, err)), nil
+		}
+
+		formatted := kubernetes.FormatNodeMaintenanceResult(result)
+		if !result.Completed {
+			return mcp.NewToolResultText(fmt.Sprintf("Maintenance mode requested on node %s, but VMs are still on it\n\n%s", name, formatted)), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Node %s is in maintenance mode\n\n%s", name, formatted)), nil
+	})
+
+	// Disable maintenance mode tool
+	disableMaintenanceModeTool := mcp.NewTool(
+		"disable_maintenance_mode",
+		mcp.WithDescription("Take a node out of maintenance mode and make it schedulable again; migrated VMs are not moved back"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The name of the node"),
+		),
+	)
+	s.mcpServer.AddTool(disableMaintenanceModeTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, ok := req.Params.Arguments["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("Node name is required"), nil
+		}
+
+		resource, err := s.resourceHandler.DisableNodeMaintenanceMode(ctx, name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to disable maintenance mode on node %s: %v", name, err)), nil
+		}
+
+		// Format the resource using the resource formatter
+		gvr := kubernetes.ResourceTypeToGVR[kubernetes.ResourceTypeNode]
+		formatted := s.resourceHandler.FormatResource(resource, gvr)
+		return mcp.NewToolResultText(fmt.Sprintf("Maintenance mode disabled on node %s\n\n%s", name, formatted)), nil
+	})
+}
+
+// getTimeoutArgument reads the optional "timeout" argument in seconds, bounded by maxWaitTimeout.
+func getTimeoutArgument(req mcp.CallToolRequest) time.Duration {
+	return getDurationArgument(req, "timeout", defaultWaitTimeout, maxWaitTimeout)
+}
+
+// getDurationArgument reads an optional duration argument in seconds, bounded by maxDuration.
+func getDurationArgument(req mcp.CallToolRequest, key string, defaultDuration, maxDuration time.Duration) time.Duration {
+	seconds, ok := req.Params.Arguments[key].(float64)
+	if !ok || seconds <= 0 {
+		return defaultDuration
+	}
+
+	duration := time.Duration(seconds * float64(time.Second))
+	if duration > maxDuration {
+		return maxDuration
+	}
+	return duration
+}
+
+// getListArgument reads an optional comma-separated string argument as a list, skipping empty entries.
+func getListArgument(req mcp.CallToolRequest, key string) []string {
+	value, _ := req.Params.Arguments[key].(string)
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getLinesArgument reads the optional "lines" argument, defaulting to defaultConsoleLines.
+func getLinesArgument(req mcp.CallToolRequest) int {
+	lines, ok := req.Params.Arguments["lines"].(float64)
+	if !ok || lines < 1 {
+		return defaultConsoleLines
+	}
+	return int(lines)
+}
+
+// formatConsoleOutput wraps captured serial console output for display.
+func formatConsoleOutput(name, namespace string, duration time.Duration, output string) string {
+	if output == "" {
+		return fmt.Sprintf("No console output from VM %s in namespace %s within %s", name, namespace, duration)
+	}
+	return fmt.Sprintf("Console output of VM %s in namespace %s (captured for %s):\n\n%s", name, namespace, duration, output)
+}
+
+// getImageCreateOptions reads the optional arguments shared by the image creation tools.
+func getImageCreateOptions(req mcp.CallToolRequest, namespace string) kubernetes.ImageCreateOptions {
+	name, _ := req.Params.Arguments["name"].(string)
+	displayName, _ := req.Params.Arguments["display_name"].(string)
+	checksum, _ := req.Params.Arguments["checksum"].(string)
+	storageClass, _ := req.Params.Arguments["storage_class"].(string)
+	description, _ := req.Params.Arguments["description"].(string)
+
+	return kubernetes.ImageCreateOptions{
+		Name:         name,
+		Namespace:    namespace,
+		DisplayName:  displayName,
+		Description:  description,
+		StorageClass: storageClass,
+		Checksum:     checksum,
+	}
 }